@@ -0,0 +1,394 @@
+// Package bitset provides a dense set of small non-negative integers.
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+const wordSize = 64
+
+// BitSet is a set of non-negative integers stored as a bit vector.
+// Memory use is proportional to the largest element, so it is best suited to dense domains such as IDs 0..N.
+type BitSet struct {
+	words []uint64
+}
+
+// New creates a new BitSet and initializes it with the provided elements.
+//
+// Example:
+//
+//	b1 := bitset.New()
+//	b2 := bitset.New(1, 64, 200)
+//	require.True(b1.IsEmpty())
+//	require.Equal([]int{1, 64, 200}, b2.ToSlice())
+func New(elements ...int) *BitSet {
+	b := &BitSet{}
+	b.Insert(elements...)
+	return b
+}
+
+// FromSet creates a new BitSet containing the elements of s.
+// It panics if s contains a negative element.
+//
+// Example:
+//
+//	b := bitset.FromSet(set.New(3, 1, 2))
+//	require.Equal([]int{1, 2, 3}, b.ToSlice())
+func FromSet(s set.Set[int]) *BitSet {
+	b := &BitSet{}
+	for e := range s.All() {
+		b.Insert(e)
+	}
+	return b
+}
+
+// ToSet converts the BitSet to a set.Set.
+//
+// Example:
+//
+//	b := bitset.New(1, 2, 3)
+//	require.True(b.ToSet().Equals(set.New(1, 2, 3)))
+func (b *BitSet) ToSet() set.Set[int] {
+	s := set.New[int]()
+	for e := range b.All() {
+		s.Insert(e)
+	}
+	return s
+}
+
+// Insert adds one or more elements to the set.
+// It panics if an element is negative.
+//
+// Example:
+//
+//	b := bitset.New()
+//	b.Insert(5)
+//	b.Insert(1, 1000)
+//	require.Equal([]int{1, 5, 1000}, b.ToSlice())
+func (b *BitSet) Insert(elements ...int) {
+	for _, e := range elements {
+		if e < 0 {
+			panic("bitset: negative element")
+		}
+		i := e / wordSize
+		if i >= len(b.words) {
+			b.grow(i + 1)
+		}
+		b.words[i] |= 1 << (e % wordSize)
+	}
+}
+
+// Remove deletes one or more elements from the set.
+//
+// Example:
+//
+//	b := bitset.New(1, 2, 3)
+//	b.Remove(2)
+//	b.Remove(3, 5000)
+//	require.Equal([]int{1}, b.ToSlice())
+func (b *BitSet) Remove(elements ...int) {
+	for _, e := range elements {
+		if e < 0 {
+			continue
+		}
+		if i := e / wordSize; i < len(b.words) {
+			b.words[i] &^= 1 << (e % wordSize)
+		}
+	}
+}
+
+// Contains checks if the set contains a specific element.
+//
+// Example:
+//
+//	b := bitset.New(1, 100)
+//	require.True(b.Contains(100))
+//	require.False(b.Contains(2))
+//	require.False(b.Contains(10000))
+func (b *BitSet) Contains(element int) bool {
+	if element < 0 {
+		return false
+	}
+	i := element / wordSize
+	return i < len(b.words) && b.words[i]&(1<<(element%wordSize)) != 0
+}
+
+// Len returns the number of elements in the set.
+//
+// Example:
+//
+//	b := bitset.New()
+//	require.Equal(0, b.Len())
+//	b.Insert(1, 63, 64, 1000, 1)
+//	require.Equal(4, b.Len())
+func (b *BitSet) Len() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty checks if the set is empty.
+//
+// Example:
+//
+//	b := bitset.New(500)
+//	require.False(b.IsEmpty())
+//	b.Remove(500)
+//	require.True(b.IsEmpty())
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals checks if two sets contain the same elements.
+//
+// Example:
+//
+//	b1 := bitset.New(1, 2)
+//	b2 := bitset.New(2, 1, 1000)
+//	b2.Remove(1000)
+//	require.True(b1.Equals(b2)) // trailing capacity does not matter
+//	require.False(b1.Equals(bitset.New(1)))
+func (b *BitSet) Equals(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i, w := range short {
+		if w != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NextSet returns the smallest element greater than or equal to i.
+// The second result is false if there is no such element.
+//
+// Example:
+//
+//	b := bitset.New(3, 70, 200)
+//	var got []int
+//	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+//		got = append(got, i)
+//	}
+//	require.Equal([]int{3, 70, 200}, got)
+func (b *BitSet) NextSet(i int) (int, bool) {
+	if i < 0 {
+		i = 0
+	}
+	wi := i / wordSize
+	if wi >= len(b.words) {
+		return 0, false
+	}
+	if w := b.words[wi] >> (i % wordSize); w != 0 {
+		return i + bits.TrailingZeros64(w), true
+	}
+	for wi++; wi < len(b.words); wi++ {
+		if w := b.words[wi]; w != 0 {
+			return wi*wordSize + bits.TrailingZeros64(w), true
+		}
+	}
+	return 0, false
+}
+
+// All returns an iterator over the elements of the set in ascending order.
+//
+// Example:
+//
+//	b := bitset.New(64, 0, 5)
+//	require.Equal([]int{0, 5, 64}, slices.Collect(b.All()))
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for wi, w := range b.words {
+			for w != 0 {
+				t := bits.TrailingZeros64(w)
+				if !yield(wi*wordSize + t) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// ToSlice returns the elements of the set in ascending order.
+//
+// Example:
+//
+//	b := bitset.New(10, 2, 7)
+//	require.Equal([]int{2, 7, 10}, b.ToSlice())
+func (b *BitSet) ToSlice() []int {
+	elements := make([]int, 0, b.Len())
+	for e := range b.All() {
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+// Clear removes all elements from the set.
+//
+// Example:
+//
+//	b := bitset.New(1, 2)
+//	b.Clear()
+//	require.True(b.IsEmpty())
+func (b *BitSet) Clear() {
+	b.words = nil
+}
+
+// Copy creates a copy of the set.
+//
+// Example:
+//
+//	b1 := bitset.New(1, 2)
+//	b2 := b1.Copy()
+//	b1.Insert(3)
+//	require.Equal([]int{1, 2}, b2.ToSlice())
+func (b *BitSet) Copy() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// UnionWith adds all elements of other to the set in place.
+//
+// Example:
+//
+//	b := bitset.New(1, 2)
+//	b.UnionWith(bitset.New(2, 300))
+//	require.Equal([]int{1, 2, 300}, b.ToSlice())
+func (b *BitSet) UnionWith(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.grow(len(other.words))
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// IntersectWith removes the elements not in other from the set in place.
+//
+// Example:
+//
+//	b := bitset.New(1, 2, 300)
+//	b.IntersectWith(bitset.New(2, 3))
+//	require.Equal([]int{2}, b.ToSlice())
+func (b *BitSet) IntersectWith(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+// DifferenceWith removes the elements of other from the set in place.
+//
+// Example:
+//
+//	b := bitset.New(1, 2, 300)
+//	b.DifferenceWith(bitset.New(2, 3))
+//	require.Equal([]int{1, 300}, b.ToSlice())
+func (b *BitSet) DifferenceWith(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+// SymmetricDifferenceWith updates the set in place to hold the elements in either set but not in both.
+//
+// Example:
+//
+//	b := bitset.New(1, 2)
+//	b.SymmetricDifferenceWith(bitset.New(2, 300))
+//	require.Equal([]int{1, 300}, b.ToSlice())
+func (b *BitSet) SymmetricDifferenceWith(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.grow(len(other.words))
+	}
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+}
+
+// Union creates a new set that is the union of the current set and another set.
+//
+// Example:
+//
+//	b1 := bitset.New(1, 2, 3)
+//	b2 := bitset.New(3, 4, 5)
+//	require.Equal([]int{1, 2, 3, 4, 5}, b1.Union(b2).ToSlice())
+//	require.Equal([]int{1, 2, 3}, b1.ToSlice()) // b1 should be unchanged
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	result := b.Copy()
+	result.UnionWith(other)
+	return result
+}
+
+// Intersection creates a new set that is the intersection of the current set and another set.
+//
+// Example:
+//
+//	b1 := bitset.New(1, 2, 3, 4)
+//	b2 := bitset.New(3, 4, 5, 6)
+//	require.Equal([]int{3, 4}, b1.Intersection(b2).ToSlice())
+func (b *BitSet) Intersection(other *BitSet) *BitSet {
+	result := b.Copy()
+	result.IntersectWith(other)
+	return result
+}
+
+// Difference creates a new set that contains elements in the current set but not in the other set.
+//
+// Example:
+//
+//	b1 := bitset.New(1, 2, 3, 4)
+//	b2 := bitset.New(3, 4, 5, 6)
+//	require.Equal([]int{1, 2}, b1.Difference(b2).ToSlice())
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	result := b.Copy()
+	result.DifferenceWith(other)
+	return result
+}
+
+// SymmetricDifference creates a new set with elements in either set but not in both.
+//
+// Example:
+//
+//	b1 := bitset.New(1, 2, 3, 4)
+//	b2 := bitset.New(3, 4, 5, 6)
+//	require.Equal([]int{1, 2, 5, 6}, b1.SymmetricDifference(b2).ToSlice())
+func (b *BitSet) SymmetricDifference(other *BitSet) *BitSet {
+	result := b.Copy()
+	result.SymmetricDifferenceWith(other)
+	return result
+}
+
+// grow extends the word slice to hold at least n words.
+func (b *BitSet) grow(n int) {
+	if n <= cap(b.words) {
+		old := len(b.words)
+		b.words = b.words[:n]
+		clear(b.words[old:])
+		return
+	}
+	words := make([]uint64, n, max(n, 2*cap(b.words)))
+	copy(words, b.words)
+	b.words = words
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
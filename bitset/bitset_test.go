@@ -0,0 +1,351 @@
+package bitset_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/bitset"
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	b1 := bitset.New()
+	b2 := bitset.New(1, 64, 200)
+	require.True(b1.IsEmpty())
+	require.Equal([]int{1, 64, 200}, b2.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestFromSet() {
+	require := require.New(s.T())
+	// testdoc begin FromSet
+	b := bitset.FromSet(set.New(3, 1, 2))
+	require.Equal([]int{1, 2, 3}, b.ToSlice())
+	// testdoc end
+	require.Panics(func() { bitset.FromSet(set.New(-1)) })
+}
+
+func (s *Suite) TestToSet() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.ToSet
+	b := bitset.New(1, 2, 3)
+	require.True(b.ToSet().Equals(set.New(1, 2, 3)))
+	// testdoc end
+}
+
+func (s *Suite) TestInsert() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Insert
+	b := bitset.New()
+	b.Insert(5)
+	b.Insert(1, 1000)
+	require.Equal([]int{1, 5, 1000}, b.ToSlice())
+	// testdoc end
+	require.Panics(func() { b.Insert(-1) })
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Remove
+	b := bitset.New(1, 2, 3)
+	b.Remove(2)
+	b.Remove(3, 5000)
+	require.Equal([]int{1}, b.ToSlice())
+	// testdoc end
+	b.Remove(-1)
+	require.Equal(1, b.Len())
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Contains
+	b := bitset.New(1, 100)
+	require.True(b.Contains(100))
+	require.False(b.Contains(2))
+	require.False(b.Contains(10000))
+	// testdoc end
+	require.False(b.Contains(-1))
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Len
+	b := bitset.New()
+	require.Equal(0, b.Len())
+	b.Insert(1, 63, 64, 1000, 1)
+	require.Equal(4, b.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.IsEmpty
+	b := bitset.New(500)
+	require.False(b.IsEmpty())
+	b.Remove(500)
+	require.True(b.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Equals
+	b1 := bitset.New(1, 2)
+	b2 := bitset.New(2, 1, 1000)
+	b2.Remove(1000)
+	require.True(b1.Equals(b2)) // trailing capacity does not matter
+	require.False(b1.Equals(bitset.New(1)))
+	// testdoc end
+	require.True(b2.Equals(b1))
+	require.False(bitset.New(1).Equals(bitset.New(1, 500)))
+}
+
+func (s *Suite) TestNextSet() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.NextSet
+	b := bitset.New(3, 70, 200)
+	var got []int
+	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+		got = append(got, i)
+	}
+	require.Equal([]int{3, 70, 200}, got)
+	// testdoc end
+	i, ok := b.NextSet(70)
+	require.True(ok)
+	require.Equal(70, i)
+	i, ok = b.NextSet(-5)
+	require.True(ok)
+	require.Equal(3, i)
+	_, ok = b.NextSet(201)
+	require.False(ok)
+	_, ok = b.NextSet(100000)
+	require.False(ok)
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.All
+	b := bitset.New(64, 0, 5)
+	require.Equal([]int{0, 5, 64}, slices.Collect(b.All()))
+	// testdoc end
+	var got []int
+	for e := range b.All() {
+		got = append(got, e)
+		break
+	}
+	require.Equal([]int{0}, got)
+}
+
+func (s *Suite) TestToSlice() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.ToSlice
+	b := bitset.New(10, 2, 7)
+	require.Equal([]int{2, 7, 10}, b.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Clear
+	b := bitset.New(1, 2)
+	b.Clear()
+	require.True(b.IsEmpty())
+	// testdoc end
+	b.Insert(3)
+	require.Equal([]int{3}, b.ToSlice())
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Copy
+	b1 := bitset.New(1, 2)
+	b2 := b1.Copy()
+	b1.Insert(3)
+	require.Equal([]int{1, 2}, b2.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestUnionWith() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.UnionWith
+	b := bitset.New(1, 2)
+	b.UnionWith(bitset.New(2, 300))
+	require.Equal([]int{1, 2, 300}, b.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestIntersectWith() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.IntersectWith
+	b := bitset.New(1, 2, 300)
+	b.IntersectWith(bitset.New(2, 3))
+	require.Equal([]int{2}, b.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestDifferenceWith() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.DifferenceWith
+	b := bitset.New(1, 2, 300)
+	b.DifferenceWith(bitset.New(2, 3))
+	require.Equal([]int{1, 300}, b.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestSymmetricDifferenceWith() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.SymmetricDifferenceWith
+	b := bitset.New(1, 2)
+	b.SymmetricDifferenceWith(bitset.New(2, 300))
+	require.Equal([]int{1, 300}, b.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Union
+	b1 := bitset.New(1, 2, 3)
+	b2 := bitset.New(3, 4, 5)
+	require.Equal([]int{1, 2, 3, 4, 5}, b1.Union(b2).ToSlice())
+	require.Equal([]int{1, 2, 3}, b1.ToSlice()) // b1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Intersection
+	b1 := bitset.New(1, 2, 3, 4)
+	b2 := bitset.New(3, 4, 5, 6)
+	require.Equal([]int{3, 4}, b1.Intersection(b2).ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.Difference
+	b1 := bitset.New(1, 2, 3, 4)
+	b2 := bitset.New(3, 4, 5, 6)
+	require.Equal([]int{1, 2}, b1.Difference(b2).ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestSymmetricDifference() {
+	require := require.New(s.T())
+	// testdoc begin BitSet.SymmetricDifference
+	b1 := bitset.New(1, 2, 3, 4)
+	b2 := bitset.New(3, 4, 5, 6)
+	require.Equal([]int{1, 2, 5, 6}, b1.SymmetricDifference(b2).ToSlice())
+	// testdoc end
+}
+
+// TestRandomized checks set algebra against set.Set.
+func (s *Suite) TestRandomized() {
+	require := require.New(s.T())
+
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 50 {
+		sa, sb := set.New[int](), set.New[int]()
+		for range r.IntN(200) {
+			sa.Insert(r.IntN(1000))
+		}
+		for range r.IntN(200) {
+			sb.Insert(r.IntN(500))
+		}
+		a, b := bitset.FromSet(sa), bitset.FromSet(sb)
+		require.True(a.Union(b).ToSet().Equals(sa.Union(sb)))
+		require.True(a.Intersection(b).ToSet().Equals(sa.Intersection(sb)))
+		require.True(a.Difference(b).ToSet().Equals(sa.Difference(sb)))
+		require.True(b.Difference(a).ToSet().Equals(sb.Difference(sa)))
+		require.True(a.SymmetricDifference(b).ToSet().Equals(sa.SymmetricDifference(sb)))
+		require.Equal(sa.Len(), a.Len())
+	}
+}
+
+const benchDomain = 1 << 16
+
+func benchElements() []int {
+	r := rand.New(rand.NewPCG(5, 6))
+	elements := make([]int, benchDomain/2)
+	for i := range elements {
+		elements[i] = r.IntN(benchDomain)
+	}
+	return elements
+}
+
+func BenchmarkInsert_BitSet(b *testing.B) {
+	elements := benchElements()
+	for b.Loop() {
+		bitset.New(elements...)
+	}
+}
+
+func BenchmarkInsert_Set(b *testing.B) {
+	elements := benchElements()
+	for b.Loop() {
+		set.New(elements...)
+	}
+}
+
+func BenchmarkContains_BitSet(b *testing.B) {
+	bs := bitset.New(benchElements()...)
+	for b.Loop() {
+		for i := range benchDomain {
+			_ = bs.Contains(i)
+		}
+	}
+}
+
+func BenchmarkContains_Set(b *testing.B) {
+	st := set.New(benchElements()...)
+	for b.Loop() {
+		for i := range benchDomain {
+			_ = st.Contains(i)
+		}
+	}
+}
+
+func BenchmarkIntersection_BitSet(b *testing.B) {
+	elements := benchElements()
+	b1 := bitset.New(elements[:len(elements)/2]...)
+	b2 := bitset.New(elements[len(elements)/2:]...)
+	for b.Loop() {
+		b1.Intersection(b2)
+	}
+}
+
+func BenchmarkIntersection_Set(b *testing.B) {
+	elements := benchElements()
+	s1 := set.New(elements[:len(elements)/2]...)
+	s2 := set.New(elements[len(elements)/2:]...)
+	for b.Loop() {
+		s1.Intersection(s2)
+	}
+}
+
+func BenchmarkLen_BitSet(b *testing.B) {
+	bs := bitset.New(benchElements()...)
+	for b.Loop() {
+		_ = bs.Len()
+	}
+}
+
+func BenchmarkLen_Set(b *testing.B) {
+	st := set.New(benchElements()...)
+	for b.Loop() {
+		_ = st.Len()
+	}
+}
@@ -0,0 +1,468 @@
+// Package cardinality provides sketches that estimate the number of distinct elements
+// in a stream using a small, fixed amount of memory.
+package cardinality
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/ysuzuki19/collections-go/probabilistic"
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*HLL[string])(nil)
+	_ encoding.BinaryUnmarshaler = (*HLL[string])(nil)
+)
+
+// ErrInvalidFormat is returned when decoding data that was not produced by HLL.MarshalBinary.
+var ErrInvalidFormat = errors.New("cardinality: invalid format")
+
+// ErrIncompatible is returned when merging sketches with different precisions.
+var ErrIncompatible = errors.New("cardinality: incompatible sketches")
+
+const (
+	// MinPrecision and MaxPrecision bound the precision accepted by New.
+	MinPrecision = 4
+	MaxPrecision = 18
+	// DefaultPrecision uses 16384 registers for a standard error of about 0.81%.
+	DefaultPrecision = 14
+
+	// sparsePrecision is the index precision used by the sparse representation.
+	sparsePrecision = 25
+
+	hllMagic      = "HL"
+	formatVersion = 1
+)
+
+// HLL is a HyperLogLog++ sketch.
+//
+// Small cardinalities are tracked in a sparse list of high-precision register updates,
+// which is nearly exact, and the sketch switches to 2^precision dense registers once the list
+// would use more memory than they do. Dense estimates use Ertl's improved estimator,
+// which is unbiased across the whole range without empirical bias-correction tables.
+//
+// An HLL must be created with New or FromSet.
+type HLL[T any] struct {
+	p uint8
+	// sparse holds idx<<6 | rho entries sorted by idx, with one entry per idx,
+	// where idx has sparsePrecision bits. It is nil once the sketch is dense.
+	sparse    []uint32
+	registers []uint8
+	hash      probabilistic.HashFunc[T]
+}
+
+// New creates an empty sketch with 2^precision registers.
+// The standard error of the estimate is about 1.04/sqrt(2^precision).
+// It panics if precision is outside [MinPrecision, MaxPrecision].
+//
+// Example:
+//
+//	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h.Add("a", "b", "a")
+//	require.Equal(uint64(2), h.Estimate())
+func New[T any](precision uint8, hash probabilistic.HashFunc[T]) *HLL[T] {
+	if precision < MinPrecision || precision > MaxPrecision {
+		panic(fmt.Sprintf("cardinality: precision %d out of range [%d, %d]", precision, MinPrecision, MaxPrecision))
+	}
+	return &HLL[T]{p: precision, sparse: []uint32{}, hash: hash}
+}
+
+// FromSet creates a sketch containing the elements of s.
+// Comparing its estimate with s.Len() shows the error of the sketch on real data.
+//
+// Example:
+//
+//	st := set.New[int]()
+//	for i := range 10000 {
+//		st.Insert(i)
+//	}
+//	h := cardinality.FromSet(st, cardinality.DefaultPrecision, probabilistic.Integer[int]())
+//	require.InEpsilon(float64(st.Len()), float64(h.Estimate()), 0.03)
+func FromSet[T comparable](s set.Set[T], precision uint8, hash probabilistic.HashFunc[T]) *HLL[T] {
+	h := New(precision, hash)
+	for e := range s.All() {
+		h.Add(e)
+	}
+	return h
+}
+
+// UnmarshalHLL decodes a sketch encoded by MarshalBinary.
+// hash must be the HashFunc the sketch was built with.
+//
+// Example:
+//
+//	h := cardinality.New(10, probabilistic.String())
+//	h.Add("a", "b")
+//	data, err := h.MarshalBinary()
+//	require.NoError(err)
+//
+//	decoded, err := cardinality.UnmarshalHLL(data, probabilistic.String())
+//	require.NoError(err)
+//	require.Equal(uint64(2), decoded.Estimate())
+func UnmarshalHLL[T any](data []byte, hash probabilistic.HashFunc[T]) (*HLL[T], error) {
+	h := &HLL[T]{hash: hash}
+	if err := h.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Add inserts one or more elements into the sketch.
+//
+// Example:
+//
+//	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h.Add("a")
+//	h.Add("b", "c")
+//	require.Equal(uint64(3), h.Estimate())
+func (h *HLL[T]) Add(elements ...T) {
+	for _, e := range elements {
+		h.AddHash(h.hash(e))
+	}
+}
+
+// AddHash inserts an element that has already been hashed.
+// The hash must come from the same function the sketch was created with.
+//
+// Example:
+//
+//	hash := probabilistic.String()
+//	h := cardinality.New(cardinality.DefaultPrecision, hash)
+//	h.AddHash(hash("a"))
+//	h.Add("a")
+//	require.Equal(uint64(1), h.Estimate())
+func (h *HLL[T]) AddHash(x uint64) {
+	if h.sparse == nil {
+		idx, rho := split(x, h.p)
+		h.registers[idx] = max(h.registers[idx], rho)
+		return
+	}
+	idx, rho := split(x, sparsePrecision)
+	h.addSparse(uint32(idx), rho)
+}
+
+// Estimate returns the estimated number of distinct elements added.
+//
+// Example:
+//
+//	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.Integer[int]())
+//	for i := range 1_000_000 {
+//		h.Add(i % 100_000)
+//	}
+//	require.InEpsilon(100_000, float64(h.Estimate()), 0.03)
+func (h *HLL[T]) Estimate() uint64 {
+	if h.sparse != nil {
+		// Linear counting over the 2^sparsePrecision high-precision registers.
+		m := float64(uint64(1) << sparsePrecision)
+		return uint64(math.Round(m * math.Log(m/(m-float64(len(h.sparse))))))
+	}
+	return uint64(math.Round(estimate(h.registers, h.p)))
+}
+
+// Precision returns the number of index bits of the sketch.
+//
+// Example:
+//
+//	h := cardinality.New(12, probabilistic.String())
+//	require.Equal(uint8(12), h.Precision())
+func (h *HLL[T]) Precision() uint8 {
+	return h.p
+}
+
+// IsEmpty checks if no element has been added.
+//
+// Example:
+//
+//	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	require.True(h.IsEmpty())
+//	h.Add("a")
+//	require.False(h.IsEmpty())
+func (h *HLL[T]) IsEmpty() bool {
+	if h.sparse != nil {
+		return len(h.sparse) == 0
+	}
+	for _, r := range h.registers {
+		if r != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear removes all elements from the sketch.
+//
+// Example:
+//
+//	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h.Add("a")
+//	h.Clear()
+//	require.Equal(uint64(0), h.Estimate())
+func (h *HLL[T]) Clear() {
+	h.sparse, h.registers = []uint32{}, nil
+}
+
+// Copy creates a copy of the sketch.
+//
+// Example:
+//
+//	h1 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h2 := h1.Copy()
+//	h1.Add("a")
+//	require.True(h2.IsEmpty())
+func (h *HLL[T]) Copy() *HLL[T] {
+	c := *h
+	if h.sparse != nil {
+		c.sparse = slices.Clone(h.sparse)
+	}
+	c.registers = slices.Clone(h.registers)
+	return &c
+}
+
+// Merge adds the elements of other to the sketch, so that it estimates the size of the union.
+// Returns ErrIncompatible if the sketches have different precisions.
+// Both sketches must use the same HashFunc.
+//
+// Example:
+//
+//	h1 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h2 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h1.Add("a", "b")
+//	h2.Add("b", "c")
+//	err := h1.Merge(h2)
+//	require.NoError(err)
+//	require.Equal(uint64(3), h1.Estimate())
+//
+//	err = h1.Merge(cardinality.New(10, probabilistic.String()))
+//	require.ErrorIs(err, cardinality.ErrIncompatible)
+func (h *HLL[T]) Merge(other *HLL[T]) error {
+	if h.p != other.p {
+		return fmt.Errorf("%w: precision %d and %d", ErrIncompatible, h.p, other.p)
+	}
+	if other.sparse != nil {
+		for _, e := range other.sparse {
+			h.mergeEntry(e)
+		}
+		return nil
+	}
+	h.toDense()
+	for i, r := range other.registers {
+		h.registers[i] = max(h.registers[i], r)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the sketch.
+// Sparse sketches are stored as delta-encoded entries, so small sketches encode to a few bytes.
+// The hash function is not encoded.
+//
+// Example:
+//
+//	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h.Add("a")
+//	data, err := h.MarshalBinary()
+//	require.NoError(err)
+//	require.Less(len(data), 16)
+func (h *HLL[T]) MarshalBinary() ([]byte, error) {
+	data := append([]byte(hllMagic), formatVersion, h.p)
+	if h.sparse == nil {
+		data = append(data, 1)
+		return append(data, h.registers...), nil
+	}
+	data = append(data, 0)
+	data = binary.AppendUvarint(data, uint64(len(h.sparse)))
+	var prev uint32
+	for _, e := range h.sparse {
+		data = binary.AppendUvarint(data, uint64(e-prev))
+		prev = e
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary, replacing its contents.
+// The sketch keeps its HashFunc, which must be the one the encoded sketch was built with.
+//
+// Example:
+//
+//	h1 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+//	h1.Add("a", "b")
+//	data, err := h1.MarshalBinary()
+//	require.NoError(err)
+//
+//	h2 := cardinality.New(4, probabilistic.String())
+//	err = h2.UnmarshalBinary(data)
+//	require.NoError(err)
+//	require.Equal(h1.Precision(), h2.Precision())
+//	require.Equal(uint64(2), h2.Estimate())
+func (h *HLL[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 5 || string(data[:2]) != hllMagic {
+		return fmt.Errorf("%w: bad magic", ErrInvalidFormat)
+	}
+	if data[2] != formatVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidFormat, data[2])
+	}
+	p, dense, data := data[3], data[4], data[5:]
+	if p < MinPrecision || p > MaxPrecision {
+		return fmt.Errorf("%w: precision %d", ErrInvalidFormat, p)
+	}
+	switch dense {
+	case 1:
+		if len(data) != 1<<p {
+			return fmt.Errorf("%w: %d registers for precision %d", ErrInvalidFormat, len(data), p)
+		}
+		for _, r := range data {
+			if int(r) > 64-int(p)+1 {
+				return fmt.Errorf("%w: register value %d", ErrInvalidFormat, r)
+			}
+		}
+		h.p, h.sparse, h.registers = p, nil, slices.Clone(data)
+		return nil
+	case 0:
+		n, k := binary.Uvarint(data)
+		if k <= 0 || n > 1<<p/4 || n > uint64(len(data)) {
+			return fmt.Errorf("%w: sparse length", ErrInvalidFormat)
+		}
+		data = data[k:]
+		sparse := make([]uint32, n)
+		var prev uint64
+		for i := range sparse {
+			delta, k := binary.Uvarint(data)
+			if k <= 0 {
+				return fmt.Errorf("%w: sparse entry %d", ErrInvalidFormat, i)
+			}
+			data = data[k:]
+			e := prev + delta
+			if (i > 0 && e>>6 <= prev>>6) || e >= 1<<(sparsePrecision+6) || e&63 == 0 || e&63 > 64-sparsePrecision+1 {
+				return fmt.Errorf("%w: sparse entry %d", ErrInvalidFormat, i)
+			}
+			sparse[i], prev = uint32(e), e
+		}
+		if len(data) != 0 {
+			return fmt.Errorf("%w: %d trailing bytes", ErrInvalidFormat, len(data))
+		}
+		h.p, h.sparse, h.registers = p, sparse, nil
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown representation %d", ErrInvalidFormat, dense)
+	}
+}
+
+// addSparse records rho for the high-precision index idx,
+// switching to the dense representation when the list grows too large.
+func (h *HLL[T]) addSparse(idx uint32, rho uint8) {
+	i, found := slices.BinarySearchFunc(h.sparse, idx, func(e, target uint32) int {
+		return cmp.Compare(e>>6, target)
+	})
+	if found {
+		if uint8(h.sparse[i]&63) < rho {
+			h.sparse[i] = idx<<6 | uint32(rho)
+		}
+		return
+	}
+	h.sparse = slices.Insert(h.sparse, i, idx<<6|uint32(rho))
+	// Each entry takes 4 bytes and each dense register 1, so switch at a quarter of the registers.
+	if len(h.sparse) > 1<<h.p/4 {
+		h.toDense()
+	}
+}
+
+// mergeEntry records a sparse entry in either representation.
+func (h *HLL[T]) mergeEntry(e uint32) {
+	if h.sparse != nil {
+		h.addSparse(e>>6, uint8(e&63))
+		return
+	}
+	idx, rho := denseEntry(e, h.p)
+	h.registers[idx] = max(h.registers[idx], rho)
+}
+
+// toDense converts the sketch to the dense representation.
+func (h *HLL[T]) toDense() {
+	if h.sparse == nil {
+		return
+	}
+	h.registers = make([]uint8, 1<<h.p)
+	for _, e := range h.sparse {
+		idx, rho := denseEntry(e, h.p)
+		h.registers[idx] = max(h.registers[idx], rho)
+	}
+	h.sparse = nil
+}
+
+// split returns the top p bits of x as a register index, and the position of the
+// first set bit in the remaining bits, counting from 1.
+func split(x uint64, p uint8) (uint64, uint8) {
+	idx := x >> (64 - p)
+	rho := uint8(bits.LeadingZeros64(x<<p|1<<(p-1))) + 1
+	return idx, rho
+}
+
+// denseEntry converts a sparse entry to the register index and value it represents at precision p.
+func denseEntry(e uint32, p uint8) (uint64, uint8) {
+	idx, rho := e>>6, uint8(e&63)
+	extra := sparsePrecision - p
+	// The low bits of the sparse index are the first bits after the dense index.
+	if tail := idx & (1<<extra - 1); tail != 0 {
+		rho = uint8(bits.LeadingZeros32(tail)-(32-int(extra))) + 1
+	} else {
+		rho += extra
+	}
+	return uint64(idx >> extra), rho
+}
+
+// estimate computes the cardinality from dense registers with the improved raw estimator
+// from Ertl, "New cardinality estimation algorithms for HyperLogLog sketches" (2017).
+func estimate(registers []uint8, p uint8) float64 {
+	q := 64 - int(p)
+	counts := make([]int, q+2)
+	for _, r := range registers {
+		counts[r]++
+	}
+	m := float64(len(registers))
+	z := m * tau(1-float64(counts[q+1])/m)
+	for k := q; k >= 1; k-- {
+		z = 0.5 * (z + float64(counts[k]))
+	}
+	z += m * sigma(float64(counts[0])/m)
+	return m * m / (2 * math.Ln2 * z)
+}
+
+func sigma(x float64) float64 {
+	if x == 1 {
+		return math.Inf(1)
+	}
+	y, z := 1.0, x
+	for {
+		x *= x
+		prev := z
+		z += x * y
+		y += y
+		if z == prev {
+			return z
+		}
+	}
+}
+
+func tau(x float64) float64 {
+	if x == 0 || x == 1 {
+		return 0
+	}
+	y, z := 1.0, 1-x
+	for {
+		x = math.Sqrt(x)
+		prev := z
+		y *= 0.5
+		z -= (1 - x) * (1 - x) * y
+		if z == prev {
+			return z / 3
+		}
+	}
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
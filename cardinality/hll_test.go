@@ -0,0 +1,284 @@
+package cardinality_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/cardinality"
+	"github.com/ysuzuki19/collections-go/probabilistic"
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h.Add("a", "b", "a")
+	require.Equal(uint64(2), h.Estimate())
+	// testdoc end
+
+	require.Panics(func() { cardinality.New(3, probabilistic.String()) })
+	require.Panics(func() { cardinality.New(19, probabilistic.String()) })
+}
+
+func (s *Suite) TestFromSet() {
+	require := require.New(s.T())
+	// testdoc begin FromSet
+	st := set.New[int]()
+	for i := range 10000 {
+		st.Insert(i)
+	}
+	h := cardinality.FromSet(st, cardinality.DefaultPrecision, probabilistic.Integer[int]())
+	require.InEpsilon(float64(st.Len()), float64(h.Estimate()), 0.03)
+	// testdoc end
+}
+
+func (s *Suite) TestUnmarshalHLL() {
+	require := require.New(s.T())
+	// testdoc begin UnmarshalHLL
+	h := cardinality.New(10, probabilistic.String())
+	h.Add("a", "b")
+	data, err := h.MarshalBinary()
+	require.NoError(err)
+
+	decoded, err := cardinality.UnmarshalHLL(data, probabilistic.String())
+	require.NoError(err)
+	require.Equal(uint64(2), decoded.Estimate())
+	// testdoc end
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("XX\x01\x0a\x00\x00"),
+		[]byte("HL\x02\x0a\x00\x00"),         // unknown version
+		[]byte("HL\x01\x03\x00\x00"),         // precision too small
+		[]byte("HL\x01\x0a\x02\x00"),         // unknown representation
+		[]byte("HL\x01\x04\x01\x00"),         // too few registers
+		[]byte("HL\x01\x04\x00\x02\x41"),     // truncated entries
+		[]byte("HL\x01\x04\x00\x01\x40"),     // zero rho
+		[]byte("HL\x01\x04\x00\x02\x41\x00"), // repeated index
+		append(data, 0),                      // trailing bytes
+	} {
+		_, err := cardinality.UnmarshalHLL(bad, probabilistic.String())
+		require.ErrorIs(err, cardinality.ErrInvalidFormat, "%q", bad)
+	}
+}
+
+func (s *Suite) TestAdd() {
+	require := require.New(s.T())
+	// testdoc begin HLL.Add
+	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h.Add("a")
+	h.Add("b", "c")
+	require.Equal(uint64(3), h.Estimate())
+	// testdoc end
+}
+
+func (s *Suite) TestAddHash() {
+	require := require.New(s.T())
+	// testdoc begin HLL.AddHash
+	hash := probabilistic.String()
+	h := cardinality.New(cardinality.DefaultPrecision, hash)
+	h.AddHash(hash("a"))
+	h.Add("a")
+	require.Equal(uint64(1), h.Estimate())
+	// testdoc end
+}
+
+func (s *Suite) TestEstimate() {
+	require := require.New(s.T())
+	// testdoc begin HLL.Estimate
+	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.Integer[int]())
+	for i := range 1_000_000 {
+		h.Add(i % 100_000)
+	}
+	require.InEpsilon(100_000, float64(h.Estimate()), 0.03)
+	// testdoc end
+}
+
+// TestAccuracy checks the estimate across the sparse range, the switch to dense registers,
+// and the large range, for several precisions.
+func (s *Suite) TestAccuracy() {
+	require := require.New(s.T())
+	for _, p := range []uint8{cardinality.MinPrecision, 10, cardinality.DefaultPrecision} {
+		// 5 standard errors keeps the test deterministic in practice
+		tolerance := 5 * 1.04 / float64(uint(1)<<(p/2))
+		h := cardinality.New(p, probabilistic.String())
+		n := 0
+		for _, target := range []int{1, 10, 100, 1000, 3000, 10000, 50000, 200000} {
+			for ; n < target; n++ {
+				h.Add(fmt.Sprint("user-", n))
+			}
+			estimate := float64(h.Estimate())
+			if n <= 1<<p/4 {
+				// still sparse: nearly exact
+				require.InDelta(float64(n), estimate, 0.01*float64(n)+1, "p=%d n=%d", p, n)
+			} else {
+				require.InEpsilon(float64(n), estimate, tolerance, "p=%d n=%d", p, n)
+			}
+		}
+	}
+}
+
+func (s *Suite) TestPrecision() {
+	require := require.New(s.T())
+	// testdoc begin HLL.Precision
+	h := cardinality.New(12, probabilistic.String())
+	require.Equal(uint8(12), h.Precision())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin HLL.IsEmpty
+	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	require.True(h.IsEmpty())
+	h.Add("a")
+	require.False(h.IsEmpty())
+	// testdoc end
+
+	dense := cardinality.New(4, probabilistic.Integer[int]())
+	for i := range 100 {
+		dense.Add(i)
+	}
+	require.False(dense.IsEmpty())
+	dense.Clear()
+	require.True(dense.IsEmpty())
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin HLL.Clear
+	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h.Add("a")
+	h.Clear()
+	require.Equal(uint64(0), h.Estimate())
+	// testdoc end
+	h.Add("a")
+	require.Equal(uint64(1), h.Estimate())
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin HLL.Copy
+	h1 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h2 := h1.Copy()
+	h1.Add("a")
+	require.True(h2.IsEmpty())
+	// testdoc end
+
+	dense := cardinality.New(4, probabilistic.Integer[int]())
+	for i := range 100 {
+		dense.Add(i)
+	}
+	c := dense.Copy()
+	for i := range 10000 {
+		dense.Add(i)
+	}
+	require.Less(c.Estimate(), dense.Estimate())
+}
+
+func (s *Suite) TestMerge() {
+	require := require.New(s.T())
+	// testdoc begin HLL.Merge
+	h1 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h2 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h1.Add("a", "b")
+	h2.Add("b", "c")
+	err := h1.Merge(h2)
+	require.NoError(err)
+	require.Equal(uint64(3), h1.Estimate())
+
+	err = h1.Merge(cardinality.New(10, probabilistic.String()))
+	require.ErrorIs(err, cardinality.ErrIncompatible)
+	// testdoc end
+}
+
+// TestMergeRepresentations checks that merging gives the same sketch as adding
+// every element to one sketch, whatever the representations of the operands.
+func (s *Suite) TestMergeRepresentations() {
+	require := require.New(s.T())
+	const p = 10
+	build := func(from, to int) *cardinality.HLL[int] {
+		h := cardinality.New(p, probabilistic.Integer[int]())
+		for i := from; i < to; i++ {
+			h.Add(i)
+		}
+		return h
+	}
+	sizes := []int{0, 50, 200, 5000} // the sparse limit at p=10 is 256
+	for _, a := range sizes {
+		for _, b := range sizes {
+			merged := build(0, a)
+			require.NoError(merged.Merge(build(a, a+b)))
+			direct := build(0, a+b)
+			require.Equal(direct.Estimate(), merged.Estimate(), "a=%d b=%d", a, b)
+
+			mergedData, err := merged.MarshalBinary()
+			require.NoError(err)
+			directData, err := direct.MarshalBinary()
+			require.NoError(err)
+			require.Equal(directData, mergedData, "a=%d b=%d", a, b)
+		}
+	}
+}
+
+func (s *Suite) TestMarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin HLL.MarshalBinary
+	h := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h.Add("a")
+	data, err := h.MarshalBinary()
+	require.NoError(err)
+	require.Less(len(data), 16)
+	// testdoc end
+
+	for i := range 10000 {
+		h.Add(fmt.Sprint(i))
+	}
+	data, err = h.MarshalBinary()
+	require.NoError(err)
+	require.Len(data, 5+1<<cardinality.DefaultPrecision)
+}
+
+func (s *Suite) TestUnmarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin HLL.UnmarshalBinary
+	h1 := cardinality.New(cardinality.DefaultPrecision, probabilistic.String())
+	h1.Add("a", "b")
+	data, err := h1.MarshalBinary()
+	require.NoError(err)
+
+	h2 := cardinality.New(4, probabilistic.String())
+	err = h2.UnmarshalBinary(data)
+	require.NoError(err)
+	require.Equal(h1.Precision(), h2.Precision())
+	require.Equal(uint64(2), h2.Estimate())
+	// testdoc end
+
+	for _, n := range []int{0, 1, 100, 4096, 50000} {
+		h := cardinality.New(cardinality.DefaultPrecision, probabilistic.Integer[int]())
+		for i := range n {
+			h.Add(i)
+		}
+		data, err := h.MarshalBinary()
+		require.NoError(err)
+		decoded, err := cardinality.UnmarshalHLL(data, probabilistic.Integer[int]())
+		require.NoError(err)
+		require.Equal(h.Estimate(), decoded.Estimate())
+		// the decoded sketch keeps working
+		decoded.Add(n)
+		h.Add(n)
+		require.Equal(h.Estimate(), decoded.Estimate())
+	}
+}
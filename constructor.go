@@ -1,7 +1,14 @@
 package collections
 
-import "github.com/ysuzuki19/collections-go/set"
+import (
+	"github.com/ysuzuki19/collections-go/multiset"
+	"github.com/ysuzuki19/collections-go/set"
+)
 
 func NewSet[T comparable](elements ...T) set.Set[T] {
 	return set.New(elements...)
 }
+
+func NewBag[T comparable](elements ...T) multiset.Bag[T] {
+	return multiset.New(elements...)
+}
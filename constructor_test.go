@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/ysuzuki19/collections-go"
+	"github.com/ysuzuki19/collections-go/multiset"
 	"github.com/ysuzuki19/collections-go/set"
 )
 
@@ -13,3 +14,9 @@ func TestSet(t *testing.T) {
 	expected := set.New(1, 2, 3)
 	require.Equal(t, expected, s)
 }
+
+func TestBag(t *testing.T) {
+	b := collections.NewBag("a", "b", "a")
+	expected := multiset.New("a", "b", "a")
+	require.Equal(t, expected, b)
+}
@@ -0,0 +1,291 @@
+// Package disjointset provides a union-find structure for tracking connected components.
+package disjointset
+
+import (
+	"iter"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+// DSU is a disjoint-set forest with path compression and union by rank.
+// Each element belongs to exactly one component, and components are merged with Union.
+type DSU[T comparable] struct {
+	index    map[T]int
+	elements []T
+	parent   []int
+	rank     []uint8
+	size     []int
+	count    int
+}
+
+// New creates a new DSU and adds each of the provided elements as a singleton component.
+//
+// Example:
+//
+//	d1 := disjointset.New[string]()
+//	d2 := disjointset.New("a", "b", "c")
+//	require.Equal(0, d1.Len())
+//	require.Equal(3, d2.Len())
+func New[T comparable](elements ...T) *DSU[T] {
+	d := &DSU[T]{
+		index: make(map[T]int),
+	}
+	d.Add(elements...)
+	return d
+}
+
+// Add adds each element that is not already present as a singleton component.
+//
+// Example:
+//
+//	d := disjointset.New("a")
+//	d.Union("a", "b")
+//	d.Add("b", "c")
+//	require.True(d.Connected("a", "b")) // existing elements keep their component
+//	require.Equal(2, d.Len())
+func (d *DSU[T]) Add(elements ...T) {
+	for _, e := range elements {
+		d.add(e)
+	}
+}
+
+// Contains checks if the element has been added.
+//
+// Example:
+//
+//	d := disjointset.New("a")
+//	require.True(d.Contains("a"))
+//	require.False(d.Contains("b"))
+func (d *DSU[T]) Contains(element T) bool {
+	_, ok := d.index[element]
+	return ok
+}
+
+// Find returns the representative of the component containing element.
+// Two elements are in the same component if and only if they have the same representative.
+// The second result is false if the element has not been added.
+//
+// Example:
+//
+//	d := disjointset.New("a", "b", "c")
+//	d.Union("a", "b")
+//	ra, _ := d.Find("a")
+//	rb, _ := d.Find("b")
+//	rc, _ := d.Find("c")
+//	require.Equal(ra, rb)
+//	require.NotEqual(ra, rc)
+//	_, ok := d.Find("z")
+//	require.False(ok)
+func (d *DSU[T]) Find(element T) (T, bool) {
+	i, ok := d.index[element]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return d.elements[d.find(i)], true
+}
+
+// Union merges the components containing a and b, adding either element if it is not present.
+// It returns false if a and b were already in the same component.
+//
+// Example:
+//
+//	d := disjointset.New[string]()
+//	require.True(d.Union("a", "b"))
+//	require.True(d.Union("b", "c"))
+//	require.False(d.Union("a", "c")) // already connected
+//	require.Equal(1, d.Len())
+func (d *DSU[T]) Union(a, b T) bool {
+	ra, rb := d.find(d.add(a)), d.find(d.add(b))
+	if ra == rb {
+		return false
+	}
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.count--
+	return true
+}
+
+// Connected checks if a and b are in the same component.
+// It returns false if either element has not been added.
+//
+// Example:
+//
+//	d := disjointset.New("c")
+//	d.Union("a", "b")
+//	require.True(d.Connected("a", "b"))
+//	require.False(d.Connected("a", "c"))
+//	require.False(d.Connected("a", "z"))
+func (d *DSU[T]) Connected(a, b T) bool {
+	i, ok := d.index[a]
+	if !ok {
+		return false
+	}
+	j, ok := d.index[b]
+	if !ok {
+		return false
+	}
+	return d.find(i) == d.find(j)
+}
+
+// Size returns the number of elements in the component containing element,
+// or 0 if the element has not been added.
+//
+// Example:
+//
+//	d := disjointset.New("c")
+//	d.Union("a", "b")
+//	require.Equal(2, d.Size("a"))
+//	require.Equal(1, d.Size("c"))
+//	require.Equal(0, d.Size("z"))
+func (d *DSU[T]) Size(element T) int {
+	i, ok := d.index[element]
+	if !ok {
+		return 0
+	}
+	return d.size[d.find(i)]
+}
+
+// Len returns the number of components.
+//
+// Example:
+//
+//	d := disjointset.New(1, 2, 3, 4)
+//	d.Union(1, 2)
+//	d.Union(3, 4)
+//	require.Equal(2, d.Len())
+func (d *DSU[T]) Len() int {
+	return d.count
+}
+
+// ElementsLen returns the number of elements across all components.
+//
+// Example:
+//
+//	d := disjointset.New(1, 2, 3)
+//	d.Union(1, 2)
+//	require.Equal(3, d.ElementsLen())
+func (d *DSU[T]) ElementsLen() int {
+	return len(d.elements)
+}
+
+// Groups returns each component as a set.
+// Components are ordered by the element of each that was added first.
+//
+// Example:
+//
+//	d := disjointset.New("a", "b", "c", "d")
+//	d.Union("a", "c")
+//	d.Union("d", "b")
+//	groups := d.Groups()
+//	require.Len(groups, 2)
+//	require.True(groups[0].Equals(set.New("a", "c")))
+//	require.True(groups[1].Equals(set.New("b", "d")))
+func (d *DSU[T]) Groups() []set.Set[T] {
+	groups := make([]set.Set[T], 0, d.count)
+	slot := make(map[int]int, d.count)
+	for i, e := range d.elements {
+		root := d.find(i)
+		g, ok := slot[root]
+		if !ok {
+			g = len(groups)
+			slot[root] = g
+			groups = append(groups, set.New[T]())
+		}
+		groups[g].Insert(e)
+	}
+	return groups
+}
+
+// All returns an iterator over the elements and their representatives, in the order they were added.
+//
+// Example:
+//
+//	d := disjointset.New(1, 2, 3)
+//	d.Union(1, 3)
+//	roots := map[int]int{}
+//	for e, root := range d.All() {
+//		roots[e] = root
+//	}
+//	require.Equal(roots[1], roots[3])
+//	require.NotEqual(roots[1], roots[2])
+func (d *DSU[T]) All() iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		for i, e := range d.elements {
+			if !yield(e, d.elements[d.find(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all elements.
+//
+// Example:
+//
+//	d := disjointset.New(1, 2)
+//	d.Union(1, 2)
+//	d.Clear()
+//	require.Equal(0, d.Len())
+//	require.False(d.Contains(1))
+func (d *DSU[T]) Clear() {
+	*d = DSU[T]{index: make(map[T]int)}
+}
+
+// Copy creates an independent copy of the DSU.
+//
+// Example:
+//
+//	d1 := disjointset.New(1, 2, 3)
+//	d2 := d1.Copy()
+//	d1.Union(1, 2)
+//	require.False(d2.Connected(1, 2))
+func (d *DSU[T]) Copy() *DSU[T] {
+	index := make(map[T]int, len(d.index))
+	for e, i := range d.index {
+		index[e] = i
+	}
+	return &DSU[T]{
+		index:    index,
+		elements: append([]T(nil), d.elements...),
+		parent:   append([]int(nil), d.parent...),
+		rank:     append([]uint8(nil), d.rank...),
+		size:     append([]int(nil), d.size...),
+		count:    d.count,
+	}
+}
+
+// add returns the index of element, adding it as a singleton component if needed.
+func (d *DSU[T]) add(element T) int {
+	if i, ok := d.index[element]; ok {
+		return i
+	}
+	i := len(d.elements)
+	d.index[element] = i
+	d.elements = append(d.elements, element)
+	d.parent = append(d.parent, i)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.count++
+	return i
+}
+
+// find returns the root index of i, compressing the path along the way.
+func (d *DSU[T]) find(i int) int {
+	root := i
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	for d.parent[i] != root {
+		d.parent[i], i = root, d.parent[i]
+	}
+	return root
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
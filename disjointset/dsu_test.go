@@ -0,0 +1,225 @@
+package disjointset_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/disjointset"
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	d1 := disjointset.New[string]()
+	d2 := disjointset.New("a", "b", "c")
+	require.Equal(0, d1.Len())
+	require.Equal(3, d2.Len())
+	// testdoc end
+	require.Equal(3, disjointset.New(1, 1, 2, 3).Len())
+}
+
+func (s *Suite) TestAdd() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Add
+	d := disjointset.New("a")
+	d.Union("a", "b")
+	d.Add("b", "c")
+	require.True(d.Connected("a", "b")) // existing elements keep their component
+	require.Equal(2, d.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Contains
+	d := disjointset.New("a")
+	require.True(d.Contains("a"))
+	require.False(d.Contains("b"))
+	// testdoc end
+}
+
+func (s *Suite) TestFind() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Find
+	d := disjointset.New("a", "b", "c")
+	d.Union("a", "b")
+	ra, _ := d.Find("a")
+	rb, _ := d.Find("b")
+	rc, _ := d.Find("c")
+	require.Equal(ra, rb)
+	require.NotEqual(ra, rc)
+	_, ok := d.Find("z")
+	require.False(ok)
+	// testdoc end
+	require.False(d.Contains("z")) // Find does not add elements
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Union
+	d := disjointset.New[string]()
+	require.True(d.Union("a", "b"))
+	require.True(d.Union("b", "c"))
+	require.False(d.Union("a", "c")) // already connected
+	require.Equal(1, d.Len())
+	// testdoc end
+	require.False(d.Union("x", "x"))
+	require.Equal(2, d.Len())
+	require.Equal(1, d.Size("x"))
+}
+
+func (s *Suite) TestConnected() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Connected
+	d := disjointset.New("c")
+	d.Union("a", "b")
+	require.True(d.Connected("a", "b"))
+	require.False(d.Connected("a", "c"))
+	require.False(d.Connected("a", "z"))
+	// testdoc end
+	require.True(d.Connected("c", "c"))
+	require.False(d.Connected("z", "z"))
+}
+
+func (s *Suite) TestSize() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Size
+	d := disjointset.New("c")
+	d.Union("a", "b")
+	require.Equal(2, d.Size("a"))
+	require.Equal(1, d.Size("c"))
+	require.Equal(0, d.Size("z"))
+	// testdoc end
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Len
+	d := disjointset.New(1, 2, 3, 4)
+	d.Union(1, 2)
+	d.Union(3, 4)
+	require.Equal(2, d.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestElementsLen() {
+	require := require.New(s.T())
+	// testdoc begin DSU.ElementsLen
+	d := disjointset.New(1, 2, 3)
+	d.Union(1, 2)
+	require.Equal(3, d.ElementsLen())
+	// testdoc end
+}
+
+func (s *Suite) TestGroups() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Groups
+	d := disjointset.New("a", "b", "c", "d")
+	d.Union("a", "c")
+	d.Union("d", "b")
+	groups := d.Groups()
+	require.Len(groups, 2)
+	require.True(groups[0].Equals(set.New("a", "c")))
+	require.True(groups[1].Equals(set.New("b", "d")))
+	// testdoc end
+	require.Empty(disjointset.New[int]().Groups())
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin DSU.All
+	d := disjointset.New(1, 2, 3)
+	d.Union(1, 3)
+	roots := map[int]int{}
+	for e, root := range d.All() {
+		roots[e] = root
+	}
+	require.Equal(roots[1], roots[3])
+	require.NotEqual(roots[1], roots[2])
+	// testdoc end
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Clear
+	d := disjointset.New(1, 2)
+	d.Union(1, 2)
+	d.Clear()
+	require.Equal(0, d.Len())
+	require.False(d.Contains(1))
+	// testdoc end
+	d.Union(1, 2)
+	require.Equal(2, d.Size(2))
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin DSU.Copy
+	d1 := disjointset.New(1, 2, 3)
+	d2 := d1.Copy()
+	d1.Union(1, 2)
+	require.False(d2.Connected(1, 2))
+	// testdoc end
+	d2.Union(2, 3)
+	require.False(d1.Connected(2, 3))
+}
+
+func (s *Suite) TestRandomized() {
+	require := require.New(s.T())
+	const n = 200
+	r := rand.New(rand.NewPCG(1, 2))
+	d := disjointset.New[int]()
+	// naive labels: component id per element
+	label := make(map[int]int)
+	for i := range n {
+		label[i] = i
+	}
+	for range 300 {
+		a, b := r.IntN(n), r.IntN(n)
+		merged := d.Union(a, b)
+		require.Equal(label[a] != label[b], merged)
+		if la, lb := label[a], label[b]; la != lb {
+			for k, v := range label {
+				if v == lb {
+					label[k] = la
+				}
+			}
+		}
+	}
+	for i := range n {
+		d.Add(i)
+	}
+
+	components := set.New[int]()
+	for _, l := range label {
+		components.Insert(l)
+	}
+	require.Equal(components.Len(), d.Len())
+	for a := range n {
+		size := 0
+		for b := range n {
+			require.Equal(label[a] == label[b], d.Connected(a, b))
+			if label[a] == label[b] {
+				size++
+			}
+		}
+		require.Equal(size, d.Size(a))
+	}
+	total := 0
+	for _, g := range d.Groups() {
+		total += g.Len()
+	}
+	require.Equal(n, total)
+}
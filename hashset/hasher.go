@@ -0,0 +1,132 @@
+package hashset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/maphash"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Hasher defines the notion of equality used by a Set.
+// Equal elements must have equal hashes.
+type Hasher[T any] interface {
+	Hash(element T) uint64
+	Equal(a, b T) bool
+}
+
+type funcHasher[T any] struct {
+	hash  func(T) uint64
+	equal func(a, b T) bool
+}
+
+func (h funcHasher[T]) Hash(element T) uint64 { return h.hash(element) }
+func (h funcHasher[T]) Equal(a, b T) bool     { return h.equal(a, b) }
+
+// HasherFunc creates a Hasher from a hash function and an equality function.
+// Elements for which equal returns true must have the same hash.
+//
+// Example:
+//
+//	type Point struct{ X, Y float64 }
+//	byX := hashset.HasherFunc(
+//		func(p Point) uint64 { return uint64(p.X) },
+//		func(a, b Point) bool { return a.X == b.X },
+//	)
+//	st := hashset.New(byX, Point{1, 2}, Point{1, 3}, Point{2, 2})
+//	require.Equal(2, st.Len())
+func HasherFunc[T any](hash func(T) uint64, equal func(a, b T) bool) Hasher[T] {
+	return funcHasher[T]{hash: hash, equal: equal}
+}
+
+// Comparable returns a Hasher for comparable types using maphash with the given seed.
+// It behaves like the built-in map equality and is useful for seeded, randomized hashing.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Comparable[int](maphash.MakeSeed()), 1, 2, 2, 3)
+//	require.Equal(3, st.Len())
+func Comparable[T comparable](seed maphash.Seed) Hasher[T] {
+	return HasherFunc(
+		func(e T) uint64 { return maphash.Comparable(seed, e) },
+		func(a, b T) bool { return a == b },
+	)
+}
+
+// Bytes returns a Hasher for byte slices that compares their contents.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Bytes(maphash.MakeSeed()))
+//	st.Insert([]byte("abc"), []byte("abc"), []byte("xyz"))
+//	require.Equal(2, st.Len())
+//	require.True(st.Contains([]byte("abc")))
+func Bytes(seed maphash.Seed) Hasher[[]byte] {
+	return HasherFunc(
+		func(e []byte) uint64 { return maphash.Bytes(seed, e) },
+		bytes.Equal,
+	)
+}
+
+// Slice returns a Hasher for slices of comparable elements that compares them element by element.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](maphash.MakeSeed()))
+//	st.Insert([]int{1, 2}, []int{1, 2}, []int{2, 1})
+//	require.Equal(2, st.Len())
+//	require.True(st.Contains([]int{2, 1}))
+func Slice[E comparable](seed maphash.Seed) Hasher[[]E] {
+	return HasherFunc(
+		func(e []E) uint64 {
+			var h maphash.Hash
+			h.SetSeed(seed)
+			var buf [8]byte
+			for _, v := range e {
+				binary.LittleEndian.PutUint64(buf[:], maphash.Comparable(seed, v))
+				_, _ = h.Write(buf[:])
+			}
+			return h.Sum64()
+		},
+		slices.Equal[[]E],
+	)
+}
+
+// FoldedString returns a Hasher for strings that are equal under Unicode simple case folding,
+// as reported by strings.EqualFold.
+//
+// Example:
+//
+//	st := hashset.New(hashset.FoldedString(maphash.MakeSeed()), "Go", "GO", "go", "Rust")
+//	require.Equal(2, st.Len())
+//	require.True(st.Contains("gO"))
+//	require.True(st.Contains("rust"))
+func FoldedString(seed maphash.Seed) Hasher[string] {
+	return HasherFunc(
+		func(e string) uint64 {
+			var h maphash.Hash
+			h.SetSeed(seed)
+			var buf [utf8.UTFMax]byte
+			for _, r := range e {
+				n := utf8.EncodeRune(buf[:], foldRune(r))
+				_, _ = h.Write(buf[:n])
+			}
+			return h.Sum64()
+		},
+		strings.EqualFold,
+	)
+}
+
+// foldRune maps r to the smallest rune of its simple case folding orbit,
+// so that every rune strings.EqualFold considers equal maps to the same value.
+func foldRune(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		smallest = min(smallest, f)
+	}
+	return smallest
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,99 @@
+package hashset_test
+
+import (
+	"hash/maphash"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/hashset"
+)
+
+type HasherSuite struct {
+	suite.Suite
+}
+
+func TestHasherSuite(t *testing.T) {
+	suite.Run(t, new(HasherSuite))
+}
+
+func (s *HasherSuite) TestHasherFunc() {
+	require := require.New(s.T())
+	// testdoc begin HasherFunc
+	type Point struct{ X, Y float64 }
+	byX := hashset.HasherFunc(
+		func(p Point) uint64 { return uint64(p.X) },
+		func(a, b Point) bool { return a.X == b.X },
+	)
+	st := hashset.New(byX, Point{1, 2}, Point{1, 3}, Point{2, 2})
+	require.Equal(2, st.Len())
+	// testdoc end
+}
+
+func (s *HasherSuite) TestComparable() {
+	require := require.New(s.T())
+	// testdoc begin Comparable
+	st := hashset.New(hashset.Comparable[int](maphash.MakeSeed()), 1, 2, 2, 3)
+	require.Equal(3, st.Len())
+	// testdoc end
+
+	seed := maphash.MakeSeed()
+	h := hashset.Comparable[string](seed)
+	require.Equal(h.Hash("a"), hashset.Comparable[string](seed).Hash("a"))
+	require.True(h.Equal("a", "a"))
+	require.False(h.Equal("a", "b"))
+}
+
+func (s *HasherSuite) TestBytes() {
+	require := require.New(s.T())
+	// testdoc begin Bytes
+	st := hashset.New(hashset.Bytes(maphash.MakeSeed()))
+	st.Insert([]byte("abc"), []byte("abc"), []byte("xyz"))
+	require.Equal(2, st.Len())
+	require.True(st.Contains([]byte("abc")))
+	// testdoc end
+
+	h := hashset.Bytes(maphash.MakeSeed())
+	require.Equal(h.Hash([]byte("abc")), h.Hash([]byte("abc")))
+	require.True(h.Equal(nil, []byte{}))
+}
+
+func (s *HasherSuite) TestSlice() {
+	require := require.New(s.T())
+	// testdoc begin Slice
+	st := hashset.New(hashset.Slice[int](maphash.MakeSeed()))
+	st.Insert([]int{1, 2}, []int{1, 2}, []int{2, 1})
+	require.Equal(2, st.Len())
+	require.True(st.Contains([]int{2, 1}))
+	// testdoc end
+
+	h := hashset.Slice[string](maphash.MakeSeed())
+	require.Equal(h.Hash([]string{"a", "b"}), h.Hash([]string{"a", "b"}))
+	require.False(h.Equal([]string{"a"}, []string{"a", "a"}))
+	require.True(h.Equal(nil, []string{}))
+}
+
+func (s *HasherSuite) TestFoldedString() {
+	require := require.New(s.T())
+	// testdoc begin FoldedString
+	st := hashset.New(hashset.FoldedString(maphash.MakeSeed()), "Go", "GO", "go", "Rust")
+	require.Equal(2, st.Len())
+	require.True(st.Contains("gO"))
+	require.True(st.Contains("rust"))
+	// testdoc end
+
+	h := hashset.FoldedString(maphash.MakeSeed())
+	// equal under strings.EqualFold implies equal hashes, including non-ASCII folding
+	for _, pair := range [][2]string{
+		{"Straße", "STRAßE"},
+		{"kelvin", "Kelvin"}, // Kelvin sign folds to k
+		{"ΣΑΣ", "σας"},
+	} {
+		require.True(strings.EqualFold(pair[0], pair[1]))
+		require.True(h.Equal(pair[0], pair[1]))
+		require.Equal(h.Hash(pair[0]), h.Hash(pair[1]), pair[0])
+	}
+	require.False(h.Equal("a", "b"))
+}
@@ -0,0 +1,298 @@
+// Package hashset provides a set with user-defined hashing and equality,
+// for element types that are not comparable or need a custom notion of equality.
+package hashset
+
+import (
+	"iter"
+	"slices"
+)
+
+// Set is a generic set whose equality is defined by a Hasher.
+// Unlike set.Set, it can hold non-comparable types such as slices,
+// or use a looser equality such as case-insensitive strings.
+//
+// When several equal elements are inserted, the first one is kept.
+// Elements must not be modified while they are in the set.
+//
+// The method examples use a seed shared by all sets, created with maphash.MakeSeed().
+type Set[T any] struct {
+	hasher  Hasher[T]
+	buckets map[uint64][]T
+	size    int
+}
+
+// New creates a new Set using hasher and initializes it with the provided elements.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](maphash.MakeSeed()))
+//	s2 := hashset.New(hashset.Slice[int](maphash.MakeSeed()), []int{1}, []int{1, 2})
+//	require.True(s1.IsEmpty())
+//	require.Equal(2, s2.Len())
+func New[T any](hasher Hasher[T], elements ...T) *Set[T] {
+	s := &Set[T]{
+		hasher:  hasher,
+		buckets: make(map[uint64][]T),
+	}
+	s.Insert(elements...)
+	return s
+}
+
+// find returns the hash of element and its index in the bucket, or -1 if absent.
+func (s *Set[T]) find(element T) (uint64, int) {
+	h := s.hasher.Hash(element)
+	for i, e := range s.buckets[h] {
+		if s.hasher.Equal(e, element) {
+			return h, i
+		}
+	}
+	return h, -1
+}
+
+// Insert adds one or more elements to the set.
+//
+// Example:
+//
+//	st := hashset.New(hashset.FoldedString(maphash.MakeSeed()))
+//	st.Insert("Go")
+//	st.Insert("go", "Rust")
+//	require.ElementsMatch([]string{"Go", "Rust"}, st.ToSlice()) // the first inserted element is kept
+func (s *Set[T]) Insert(elements ...T) {
+	for _, element := range elements {
+		h, i := s.find(element)
+		if i >= 0 {
+			continue
+		}
+		s.buckets[h] = append(s.buckets[h], element)
+		s.size++
+	}
+}
+
+// Remove deletes one or more elements from the set.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2}, []int{3})
+//	st.Remove([]int{2})
+//	st.Remove([]int{3}, []int{4})
+//	require.True(st.Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+func (s *Set[T]) Remove(elements ...T) {
+	for _, element := range elements {
+		h, i := s.find(element)
+		if i < 0 {
+			continue
+		}
+		if bucket := s.buckets[h]; len(bucket) == 1 {
+			delete(s.buckets, h)
+		} else {
+			s.buckets[h] = slices.Delete(bucket, i, i+1)
+		}
+		s.size--
+	}
+}
+
+// Contains checks if the set contains an element equal to element.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](seed), []int{1, 2})
+//	require.True(st.Contains([]int{1, 2}))
+//	require.False(st.Contains([]int{1}))
+func (s *Set[T]) Contains(element T) bool {
+	_, i := s.find(element)
+	return i >= 0
+}
+
+// Len returns the number of elements in the set.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](seed))
+//	require.Equal(0, st.Len())
+//	st.Insert([]int{1}, []int{1}, []int{2})
+//	require.Equal(2, st.Len())
+func (s *Set[T]) Len() int {
+	return s.size
+}
+
+// IsEmpty checks if the set is empty.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](seed))
+//	require.True(st.IsEmpty())
+//	st.Insert([]int{})
+//	require.False(st.IsEmpty())
+func (s *Set[T]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// Equals checks if two sets contain the same elements.
+// Membership in other is tested with other's hasher.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+//	require.True(s1.Equals(hashset.New(hashset.Slice[int](seed), []int{2}, []int{1})))
+//	require.False(s1.Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+func (s *Set[T]) Equals(other *Set[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for e := range s.All() {
+		if !other.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the elements of the set.
+// The iteration order is not specified.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](seed), []int{1, 2}, []int{3})
+//	total := 0
+//	for e := range st.All() {
+//		total += len(e)
+//	}
+//	require.Equal(3, total)
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, bucket := range s.buckets {
+			for _, e := range bucket {
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ToSlice converts the set to a slice of its elements.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2, 3})
+//	require.ElementsMatch([][]int{{1}, {2, 3}}, st.ToSlice())
+func (s *Set[T]) ToSlice() []T {
+	elements := make([]T, 0, s.size)
+	for e := range s.All() {
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+// Clear removes all elements from the set.
+//
+// Example:
+//
+//	st := hashset.New(hashset.Slice[int](seed), []int{1})
+//	st.Clear()
+//	require.True(st.IsEmpty())
+func (s *Set[T]) Clear() {
+	s.buckets = make(map[uint64][]T)
+	s.size = 0
+}
+
+// Copy creates a shallow copy of the set using the same hasher.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](seed), []int{1})
+//	s2 := s1.Copy()
+//	s1.Insert([]int{2})
+//	require.True(s2.Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+func (s *Set[T]) Copy() *Set[T] {
+	newSet := New(s.hasher)
+	for h, bucket := range s.buckets {
+		newSet.buckets[h] = slices.Clone(bucket)
+	}
+	newSet.size = s.size
+	return newSet
+}
+
+// Merge adds all elements from another set into the current set.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+//	s1.Merge(hashset.New(hashset.Slice[int](seed), []int{2}, []int{3}))
+//	require.True(s1.Equals(hashset.New(hashset.Slice[int](seed), []int{1}, []int{2}, []int{3})))
+func (s *Set[T]) Merge(other *Set[T]) {
+	for e := range other.All() {
+		s.Insert(e)
+	}
+}
+
+// Union creates a new set that is the union of the current set and another set.
+// The result uses the hasher of the current set.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+//	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+//	require.True(s1.Union(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{1}, []int{2}, []int{3})))
+//	require.Equal(2, s1.Len()) // s1 should be unchanged
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := s.Copy()
+	result.Merge(other)
+	return result
+}
+
+// Intersection creates a new set that is the intersection of the current set and another set.
+// The result uses the hasher of the current set.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+//	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+//	require.True(s1.Intersection(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{2})))
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	result := New(s.hasher)
+	for e := range s.All() {
+		if other.Contains(e) {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+// Difference creates a new set that contains elements in the current set but not in the other set.
+// The result uses the hasher of the current set.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+//	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+//	require.True(s1.Difference(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := New(s.hasher)
+	for e := range s.All() {
+		if !other.Contains(e) {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference creates a new set with elements in either set but not in both.
+// The result uses the hasher of the current set.
+//
+// Example:
+//
+//	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+//	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+//	require.True(s1.SymmetricDifference(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{1}, []int{3})))
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	result := s.Difference(other)
+	for e := range other.All() {
+		if !s.Contains(e) {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
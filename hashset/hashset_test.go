@@ -0,0 +1,212 @@
+package hashset_test
+
+import (
+	"hash/maphash"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/hashset"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+var seed = maphash.MakeSeed()
+
+// newIntSlices creates a set of int slices hashed by content.
+func newIntSlices(elements ...[]int) *hashset.Set[[]int] {
+	return hashset.New(hashset.Slice[int](seed), elements...)
+}
+
+// collide hashes every element to the same bucket to exercise collision handling.
+var collide = hashset.HasherFunc(
+	func(int) uint64 { return 0 },
+	func(a, b int) bool { return a == b },
+)
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	s1 := hashset.New(hashset.Slice[int](maphash.MakeSeed()))
+	s2 := hashset.New(hashset.Slice[int](maphash.MakeSeed()), []int{1}, []int{1, 2})
+	require.True(s1.IsEmpty())
+	require.Equal(2, s2.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestInsert() {
+	require := require.New(s.T())
+	// testdoc begin Set.Insert
+	st := hashset.New(hashset.FoldedString(maphash.MakeSeed()))
+	st.Insert("Go")
+	st.Insert("go", "Rust")
+	require.ElementsMatch([]string{"Go", "Rust"}, st.ToSlice()) // the first inserted element is kept
+	// testdoc end
+
+	c := hashset.New(collide, 1, 2, 3, 2)
+	require.Equal(3, c.Len())
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin Set.Remove
+	st := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2}, []int{3})
+	st.Remove([]int{2})
+	st.Remove([]int{3}, []int{4})
+	require.True(st.Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+	// testdoc end
+
+	c := hashset.New(collide, 1, 2, 3)
+	c.Remove(2)
+	require.ElementsMatch([]int{1, 3}, c.ToSlice())
+	c.Remove(1, 3, 3)
+	require.True(c.IsEmpty())
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Set.Contains
+	st := hashset.New(hashset.Slice[int](seed), []int{1, 2})
+	require.True(st.Contains([]int{1, 2}))
+	require.False(st.Contains([]int{1}))
+	// testdoc end
+	c := hashset.New(collide, 1, 2)
+	require.True(c.Contains(2))
+	require.False(c.Contains(3))
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin Set.Len
+	st := hashset.New(hashset.Slice[int](seed))
+	require.Equal(0, st.Len())
+	st.Insert([]int{1}, []int{1}, []int{2})
+	require.Equal(2, st.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsEmpty
+	st := hashset.New(hashset.Slice[int](seed))
+	require.True(st.IsEmpty())
+	st.Insert([]int{})
+	require.False(st.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin Set.Equals
+	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+	require.True(s1.Equals(hashset.New(hashset.Slice[int](seed), []int{2}, []int{1})))
+	require.False(s1.Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+	// testdoc end
+	require.False(s1.Equals(newIntSlices([]int{1}, []int{3})))
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin Set.All
+	st := hashset.New(hashset.Slice[int](seed), []int{1, 2}, []int{3})
+	total := 0
+	for e := range st.All() {
+		total += len(e)
+	}
+	require.Equal(3, total)
+	// testdoc end
+
+	n := 0
+	for range hashset.New(collide, 1, 2, 3).All() {
+		n++
+		break
+	}
+	require.Equal(1, n)
+}
+
+func (s *Suite) TestToSlice() {
+	require := require.New(s.T())
+	// testdoc begin Set.ToSlice
+	st := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2, 3})
+	require.ElementsMatch([][]int{{1}, {2, 3}}, st.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin Set.Clear
+	st := hashset.New(hashset.Slice[int](seed), []int{1})
+	st.Clear()
+	require.True(st.IsEmpty())
+	// testdoc end
+	st.Insert([]int{2})
+	require.Equal(1, st.Len())
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin Set.Copy
+	s1 := hashset.New(hashset.Slice[int](seed), []int{1})
+	s2 := s1.Copy()
+	s1.Insert([]int{2})
+	require.True(s2.Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+	// testdoc end
+
+	c1 := hashset.New(collide, 1, 2, 3)
+	c2 := c1.Copy()
+	c1.Remove(1)
+	require.ElementsMatch([]int{1, 2, 3}, c2.ToSlice())
+}
+
+func (s *Suite) TestMerge() {
+	require := require.New(s.T())
+	// testdoc begin Set.Merge
+	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+	s1.Merge(hashset.New(hashset.Slice[int](seed), []int{2}, []int{3}))
+	require.True(s1.Equals(hashset.New(hashset.Slice[int](seed), []int{1}, []int{2}, []int{3})))
+	// testdoc end
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Set.Union
+	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+	require.True(s1.Union(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{1}, []int{2}, []int{3})))
+	require.Equal(2, s1.Len()) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin Set.Intersection
+	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+	require.True(s1.Intersection(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{2})))
+	// testdoc end
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin Set.Difference
+	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+	require.True(s1.Difference(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{1})))
+	// testdoc end
+}
+
+func (s *Suite) TestSymmetricDifference() {
+	require := require.New(s.T())
+	// testdoc begin Set.SymmetricDifference
+	s1 := hashset.New(hashset.Slice[int](seed), []int{1}, []int{2})
+	s2 := hashset.New(hashset.Slice[int](seed), []int{2}, []int{3})
+	require.True(s1.SymmetricDifference(s2).Equals(hashset.New(hashset.Slice[int](seed), []int{1}, []int{3})))
+	// testdoc end
+}
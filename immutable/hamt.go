@@ -0,0 +1,188 @@
+package immutable
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"slices"
+)
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+)
+
+// seed is shared by every set so that sets built separately can still share structure.
+var seed = maphash.MakeSeed()
+
+func hashOf[T comparable](v T) uint64 {
+	return maphash.Comparable(seed, v)
+}
+
+// owner identifies the builder allowed to mutate a node in place.
+// It must not be zero-sized so that distinct owners have distinct addresses.
+type owner struct{ _ byte }
+
+// node is a bitmap-indexed node of a hash array mapped trie.
+// Bit i of bitmap is set when the node has an entry for the 5-bit hash chunk i,
+// and entries are stored in chunk order.
+type node[T comparable] struct {
+	owner   *owner
+	bitmap  uint32
+	entries []entry[T]
+}
+
+// entry is either a subtree (child != nil) or a leaf holding the values
+// that share the same full 64-bit hash.
+type entry[T comparable] struct {
+	hash   uint64
+	values []T
+	child  *node[T]
+}
+
+func position(bitmap uint32, hash uint64, shift uint) (bit uint32, index int) {
+	bit = 1 << ((hash >> shift) & levelMask)
+	return bit, bits.OnesCount32(bitmap & (bit - 1))
+}
+
+// editable returns n itself if it is owned by o, otherwise a copy owned by o.
+// A nil owner always copies, which is how persistent updates are made.
+func (n *node[T]) editable(o *owner) *node[T] {
+	if o != nil && n.owner == o {
+		return n
+	}
+	return &node[T]{owner: o, bitmap: n.bitmap, entries: slices.Clone(n.entries)}
+}
+
+func (n *node[T]) contains(hash uint64, shift uint, v T) bool {
+	for {
+		bit, i := position(n.bitmap, hash, shift)
+		if n.bitmap&bit == 0 {
+			return false
+		}
+		e := n.entries[i]
+		if e.child == nil {
+			return e.hash == hash && slices.Contains(e.values, v)
+		}
+		n = e.child
+		shift += bitsPerLevel
+	}
+}
+
+// insert returns the node with v added and whether v was newly added.
+func (n *node[T]) insert(o *owner, hash uint64, shift uint, v T) (*node[T], bool) {
+	bit, i := position(n.bitmap, hash, shift)
+	if n.bitmap&bit == 0 {
+		m := n.editable(o)
+		m.entries = slices.Insert(m.entries, i, entry[T]{hash: hash, values: []T{v}})
+		m.bitmap |= bit
+		return m, true
+	}
+
+	e := n.entries[i]
+	switch {
+	case e.child != nil:
+		child, added := e.child.insert(o, hash, shift+bitsPerLevel, v)
+		if !added {
+			return n, false
+		}
+		m := n.editable(o)
+		m.entries[i].child = child
+		return m, true
+	case e.hash == hash:
+		if slices.Contains(e.values, v) {
+			return n, false
+		}
+		m := n.editable(o)
+		m.entries[i].values = append(slices.Clip(e.values), v)
+		return m, true
+	default:
+		m := n.editable(o)
+		m.entries[i] = entry[T]{child: newPair(o, shift+bitsPerLevel, e, entry[T]{hash: hash, values: []T{v}})}
+		return m, true
+	}
+}
+
+// newPair creates the subtree holding two leaves whose hashes differ.
+func newPair[T comparable](o *owner, shift uint, a, b entry[T]) *node[T] {
+	ia := (a.hash >> shift) & levelMask
+	ib := (b.hash >> shift) & levelMask
+	if ia == ib {
+		child := newPair(o, shift+bitsPerLevel, a, b)
+		return &node[T]{owner: o, bitmap: 1 << ia, entries: []entry[T]{{child: child}}}
+	}
+	if ia > ib {
+		a, b = b, a
+	}
+	return &node[T]{owner: o, bitmap: 1<<ia | 1<<ib, entries: []entry[T]{a, b}}
+}
+
+// remove returns the node with v removed, or nil if the node became empty,
+// and whether v was present.
+func (n *node[T]) remove(o *owner, hash uint64, shift uint, v T) (*node[T], bool) {
+	bit, i := position(n.bitmap, hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	e := n.entries[i]
+	if e.child != nil {
+		child, removed := e.child.remove(o, hash, shift+bitsPerLevel, v)
+		if !removed {
+			return n, false
+		}
+		m := n.editable(o)
+		switch {
+		case child == nil:
+			m.deleteEntry(bit, i)
+		case len(child.entries) == 1 && child.entries[0].child == nil:
+			// Pull a lone leaf up so the trie stays as shallow as possible.
+			m.entries[i] = child.entries[0]
+		default:
+			m.entries[i].child = child
+		}
+		if len(m.entries) == 0 {
+			return nil, true
+		}
+		return m, true
+	}
+
+	if e.hash != hash {
+		return n, false
+	}
+	k := slices.Index(e.values, v)
+	if k < 0 {
+		return n, false
+	}
+	m := n.editable(o)
+	if len(e.values) == 1 {
+		m.deleteEntry(bit, i)
+		if len(m.entries) == 0 {
+			return nil, true
+		}
+		return m, true
+	}
+	m.entries[i].values = slices.Delete(slices.Clone(e.values), k, k+1)
+	return m, true
+}
+
+func (n *node[T]) deleteEntry(bit uint32, i int) {
+	n.entries = slices.Delete(n.entries, i, i+1)
+	n.bitmap &^= bit
+}
+
+func (n *node[T]) all(yield func(T) bool) bool {
+	for _, e := range n.entries {
+		if e.child != nil {
+			if !e.child.all(yield) {
+				return false
+			}
+			continue
+		}
+		for _, v := range e.values {
+			if !yield(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package immutable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCollisions drives the trie with hand-picked hashes, since real hash collisions
+// cannot be produced on demand.
+func TestCollisions(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		same    = 0x1234_5678_9abc_def0
+		sibling = same ^ 1<<63 // shares every chunk except the last level
+	)
+	root := &node[string]{}
+	var added bool
+	for _, v := range []string{"a", "b", "c"} {
+		root, added = root.insert(nil, same, 0, v)
+		require.True(added)
+	}
+	root, added = root.insert(nil, same, 0, "b")
+	require.False(added)
+	root, added = root.insert(nil, sibling, 0, "d")
+	require.True(added)
+
+	for _, v := range []string{"a", "b", "c"} {
+		require.True(root.contains(same, 0, v))
+	}
+	require.True(root.contains(sibling, 0, "d"))
+	require.False(root.contains(same, 0, "d"))
+
+	var got []string
+	root.all(func(v string) bool {
+		got = append(got, v)
+		return true
+	})
+	require.ElementsMatch([]string{"a", "b", "c", "d"}, got)
+
+	var removed bool
+	root, removed = root.remove(nil, same, 0, "b")
+	require.True(removed)
+	root, removed = root.remove(nil, same, 0, "b")
+	require.False(removed)
+	root, removed = root.remove(nil, sibling, 0, "x")
+	require.False(removed)
+	require.False(root.contains(same, 0, "b"))
+	require.True(root.contains(same, 0, "a"))
+
+	// removing the sibling collapses the deep path back to a single leaf
+	root, removed = root.remove(nil, sibling, 0, "d")
+	require.True(removed)
+	require.Len(root.entries, 1)
+	require.Nil(root.entries[0].child)
+
+	for _, v := range []string{"a", "c"} {
+		root, removed = root.remove(nil, same, 0, v)
+		require.True(removed)
+	}
+	require.Nil(root)
+}
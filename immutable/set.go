@@ -0,0 +1,383 @@
+// Package immutable provides a persistent set that shares structure between versions.
+package immutable
+
+import (
+	"iter"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+// Set is a persistent generic set backed by a hash array mapped trie.
+// A Set is never modified: With, Without and the set operations return new versions
+// that share all untouched nodes with the original, so keeping many versions is cheap.
+//
+// The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	root *node[T]
+	size int
+}
+
+// New creates a new Set containing the provided elements.
+//
+// Example:
+//
+//	s1 := immutable.New[int]()
+//	s2 := immutable.New(1, 2, 3, 2)
+//	var s3 immutable.Set[string] // the zero value is an empty set
+//	require.True(s1.IsEmpty())
+//	require.Equal(3, s2.Len())
+//	require.True(s3.IsEmpty())
+func New[T comparable](elements ...T) Set[T] {
+	b := NewBuilder[T]()
+	b.Insert(elements...)
+	return b.Build()
+}
+
+// FromSet creates a new Set containing the elements of s.
+//
+// Example:
+//
+//	st := immutable.FromSet(set.New(1, 2, 3))
+//	require.True(st.Equals(immutable.New(1, 2, 3)))
+func FromSet[T comparable](s set.Set[T]) Set[T] {
+	b := NewBuilder[T]()
+	for e := range s.All() {
+		b.Insert(e)
+	}
+	return b.Build()
+}
+
+// ToSet converts the Set to a mutable set.Set.
+//
+// Example:
+//
+//	st := immutable.New(1, 2, 3)
+//	mutable := st.ToSet()
+//	mutable.Insert(4)
+//	require.True(mutable.Equals(set.New(1, 2, 3, 4)))
+//	require.Equal(3, st.Len()) // st should be unchanged
+func (s Set[T]) ToSet() set.Set[T] {
+	result := set.New[T]()
+	for e := range s.All() {
+		result.Insert(e)
+	}
+	return result
+}
+
+// With returns a version of the set that also contains element.
+// If the element is already present, the same set is returned.
+//
+// Example:
+//
+//	v1 := immutable.New(1, 2)
+//	v2 := v1.With(3)
+//	require.True(v2.Contains(3))
+//	require.False(v1.Contains(3)) // v1 should be unchanged
+func (s Set[T]) With(element T) Set[T] {
+	root := s.root
+	if root == nil {
+		root = &node[T]{}
+	}
+	root, added := root.insert(nil, hashOf(element), 0, element)
+	if !added {
+		return s
+	}
+	return Set[T]{root: root, size: s.size + 1}
+}
+
+// Without returns a version of the set that does not contain element.
+// If the element is not present, the same set is returned.
+//
+// Example:
+//
+//	v1 := immutable.New(1, 2, 3)
+//	v2 := v1.Without(2)
+//	require.False(v2.Contains(2))
+//	require.True(v1.Contains(2)) // v1 should be unchanged
+func (s Set[T]) Without(element T) Set[T] {
+	if s.root == nil {
+		return s
+	}
+	root, removed := s.root.remove(nil, hashOf(element), 0, element)
+	if !removed {
+		return s
+	}
+	return Set[T]{root: root, size: s.size - 1}
+}
+
+// Contains checks if the set contains a specific element.
+//
+// Example:
+//
+//	st := immutable.New("a", "b")
+//	require.True(st.Contains("a"))
+//	require.False(st.Contains("c"))
+func (s Set[T]) Contains(element T) bool {
+	return s.root != nil && s.root.contains(hashOf(element), 0, element)
+}
+
+// Len returns the number of elements in the set.
+//
+// Example:
+//
+//	st := immutable.New[int]()
+//	require.Equal(0, st.Len())
+//	require.Equal(2, st.With(1).With(2).With(1).Len())
+func (s Set[T]) Len() int {
+	return s.size
+}
+
+// IsEmpty checks if the set is empty.
+//
+// Example:
+//
+//	st := immutable.New[int]()
+//	require.True(st.IsEmpty())
+//	require.False(st.With(1).IsEmpty())
+func (s Set[T]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// Equals checks if two sets contain the same elements.
+//
+// Example:
+//
+//	s1 := immutable.New(1, 2, 3)
+//	require.True(s1.Equals(immutable.New(3, 2, 1)))
+//	require.False(s1.Equals(immutable.New(1, 2)))
+func (s Set[T]) Equals(other Set[T]) bool {
+	if s.size != other.size {
+		return false
+	}
+	if s.root == other.root {
+		return true
+	}
+	for e := range s.All() {
+		if !other.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the elements of the set.
+// The iteration order is not specified.
+//
+// Example:
+//
+//	st := immutable.New(1, 2, 3)
+//	sum := 0
+//	for e := range st.All() {
+//		sum += e
+//	}
+//	require.Equal(6, sum)
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.root != nil {
+			s.root.all(yield)
+		}
+	}
+}
+
+// ToSlice converts the set to a slice of its elements.
+//
+// Example:
+//
+//	st := immutable.New(1, 2, 3)
+//	require.ElementsMatch([]int{1, 2, 3}, st.ToSlice())
+func (s Set[T]) ToSlice() []T {
+	elements := make([]T, 0, s.size)
+	for e := range s.All() {
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+// Union returns a set containing the elements of both sets.
+// The elements of the smaller set are added to the larger one, so the result shares
+// structure with the larger set and costs O(m log n) for sets of sizes n >= m.
+//
+// Example:
+//
+//	s1 := immutable.New(1, 2, 3)
+//	s2 := immutable.New(3, 4, 5)
+//	require.True(s1.Union(s2).Equals(immutable.New(1, 2, 3, 4, 5)))
+//	require.True(s1.Equals(immutable.New(1, 2, 3))) // s1 should be unchanged
+//	require.True(s2.Equals(immutable.New(3, 4, 5))) // s2 should be unchanged
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	larger, smaller := s, other
+	if larger.size < smaller.size {
+		larger, smaller = smaller, larger
+	}
+	if smaller.size == 0 {
+		return larger
+	}
+	b := larger.ToBuilder()
+	for e := range smaller.All() {
+		b.Insert(e)
+	}
+	return b.Build()
+}
+
+// Intersection returns a set containing the elements present in both sets.
+//
+// Example:
+//
+//	s1 := immutable.New(1, 2, 3, 4)
+//	s2 := immutable.New(3, 4, 5, 6)
+//	require.True(s1.Intersection(s2).Equals(immutable.New(3, 4)))
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	larger, smaller := s, other
+	if larger.size < smaller.size {
+		larger, smaller = smaller, larger
+	}
+	b := NewBuilder[T]()
+	for e := range smaller.All() {
+		if larger.Contains(e) {
+			b.Insert(e)
+		}
+	}
+	return b.Build()
+}
+
+// Difference returns a set containing the elements of the current set that are not in the other set.
+// The result shares structure with the current set.
+//
+// Example:
+//
+//	s1 := immutable.New(1, 2, 3, 4)
+//	s2 := immutable.New(3, 4, 5, 6)
+//	require.True(s1.Difference(s2).Equals(immutable.New(1, 2)))
+//	require.True(s1.Equals(immutable.New(1, 2, 3, 4))) // s1 should be unchanged
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	b := s.ToBuilder()
+	for e := range other.All() {
+		b.Remove(e)
+	}
+	return b.Build()
+}
+
+// ToBuilder returns a Builder initialized with the elements of the set.
+// The set itself is not affected by changes made through the builder.
+//
+// Example:
+//
+//	v1 := immutable.New(1, 2)
+//	b := v1.ToBuilder()
+//	b.Insert(3)
+//	b.Remove(1)
+//	v2 := b.Build()
+//	require.True(v2.Equals(immutable.New(2, 3)))
+//	require.True(v1.Equals(immutable.New(1, 2))) // v1 should be unchanged
+func (s Set[T]) ToBuilder() *Builder[T] {
+	return &Builder[T]{root: s.root, size: s.size, owner: &owner{}}
+}
+
+// Builder is a transient, mutable view of a Set for efficient batch construction.
+// Nodes created by a builder are updated in place until Build is called,
+// avoiding the path copying that With and Without perform for every change.
+// A Builder must not be used concurrently.
+type Builder[T comparable] struct {
+	root  *node[T]
+	size  int
+	owner *owner
+}
+
+// NewBuilder creates an empty Builder.
+//
+// Example:
+//
+//	b := immutable.NewBuilder[int]()
+//	for i := range 1000 {
+//		b.Insert(i)
+//	}
+//	st := b.Build()
+//	require.Equal(1000, st.Len())
+func NewBuilder[T comparable]() *Builder[T] {
+	return &Builder[T]{owner: &owner{}}
+}
+
+// Insert adds one or more elements.
+//
+// Example:
+//
+//	b := immutable.NewBuilder[string]()
+//	b.Insert("a")
+//	b.Insert("b", "a")
+//	require.Equal(2, b.Len())
+func (b *Builder[T]) Insert(elements ...T) {
+	for _, e := range elements {
+		if b.root == nil {
+			b.root = &node[T]{owner: b.owner}
+		}
+		var added bool
+		b.root, added = b.root.insert(b.owner, hashOf(e), 0, e)
+		if added {
+			b.size++
+		}
+	}
+}
+
+// Remove deletes one or more elements.
+//
+// Example:
+//
+//	b := immutable.New(1, 2, 3).ToBuilder()
+//	b.Remove(2, 4)
+//	require.Equal(2, b.Len())
+func (b *Builder[T]) Remove(elements ...T) {
+	for _, e := range elements {
+		if b.root == nil {
+			return
+		}
+		var removed bool
+		b.root, removed = b.root.remove(b.owner, hashOf(e), 0, e)
+		if removed {
+			b.size--
+		}
+	}
+}
+
+// Contains checks if the builder contains a specific element.
+//
+// Example:
+//
+//	b := immutable.NewBuilder[int]()
+//	b.Insert(1)
+//	require.True(b.Contains(1))
+//	require.False(b.Contains(2))
+func (b *Builder[T]) Contains(element T) bool {
+	return b.root != nil && b.root.contains(hashOf(element), 0, element)
+}
+
+// Len returns the number of elements in the builder.
+//
+// Example:
+//
+//	b := immutable.NewBuilder[int]()
+//	b.Insert(1, 2, 2)
+//	require.Equal(2, b.Len())
+func (b *Builder[T]) Len() int {
+	return b.size
+}
+
+// Build returns an immutable Set with the current elements.
+// The builder remains usable; later changes do not affect the returned set.
+//
+// Example:
+//
+//	b := immutable.NewBuilder[int]()
+//	b.Insert(1, 2)
+//	v1 := b.Build()
+//	b.Insert(3) // does not affect v1
+//	v2 := b.Build()
+//	require.Equal(2, v1.Len())
+//	require.Equal(3, v2.Len())
+func (b *Builder[T]) Build() Set[T] {
+	s := Set[T]{root: b.root, size: b.size}
+	// Give up ownership so the nodes now shared with s are copied before the next change.
+	b.owner = &owner{}
+	return s
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
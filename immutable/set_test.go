@@ -0,0 +1,334 @@
+package immutable_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/immutable"
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	s1 := immutable.New[int]()
+	s2 := immutable.New(1, 2, 3, 2)
+	var s3 immutable.Set[string] // the zero value is an empty set
+	require.True(s1.IsEmpty())
+	require.Equal(3, s2.Len())
+	require.True(s3.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestFromSet() {
+	require := require.New(s.T())
+	// testdoc begin FromSet
+	st := immutable.FromSet(set.New(1, 2, 3))
+	require.True(st.Equals(immutable.New(1, 2, 3)))
+	// testdoc end
+}
+
+func (s *Suite) TestToSet() {
+	require := require.New(s.T())
+	// testdoc begin Set.ToSet
+	st := immutable.New(1, 2, 3)
+	mutable := st.ToSet()
+	mutable.Insert(4)
+	require.True(mutable.Equals(set.New(1, 2, 3, 4)))
+	require.Equal(3, st.Len()) // st should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestWith() {
+	require := require.New(s.T())
+	// testdoc begin Set.With
+	v1 := immutable.New(1, 2)
+	v2 := v1.With(3)
+	require.True(v2.Contains(3))
+	require.False(v1.Contains(3)) // v1 should be unchanged
+	// testdoc end
+	require.Equal(2, v1.Len())
+	require.Equal(3, v2.Len())
+	require.True(v2.With(3).Equals(v2))
+
+	var zero immutable.Set[int]
+	require.True(zero.With(1).Contains(1))
+	require.True(zero.IsEmpty())
+}
+
+func (s *Suite) TestWithout() {
+	require := require.New(s.T())
+	// testdoc begin Set.Without
+	v1 := immutable.New(1, 2, 3)
+	v2 := v1.Without(2)
+	require.False(v2.Contains(2))
+	require.True(v1.Contains(2)) // v1 should be unchanged
+	// testdoc end
+	require.Equal(2, v2.Len())
+	require.Equal(2, v2.Without(99).Len())
+	require.True(v2.Without(1).Without(3).IsEmpty())
+
+	var zero immutable.Set[int]
+	require.True(zero.Without(1).IsEmpty())
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Set.Contains
+	st := immutable.New("a", "b")
+	require.True(st.Contains("a"))
+	require.False(st.Contains("c"))
+	// testdoc end
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin Set.Len
+	st := immutable.New[int]()
+	require.Equal(0, st.Len())
+	require.Equal(2, st.With(1).With(2).With(1).Len())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsEmpty
+	st := immutable.New[int]()
+	require.True(st.IsEmpty())
+	require.False(st.With(1).IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin Set.Equals
+	s1 := immutable.New(1, 2, 3)
+	require.True(s1.Equals(immutable.New(3, 2, 1)))
+	require.False(s1.Equals(immutable.New(1, 2)))
+	// testdoc end
+	require.False(s1.Equals(immutable.New(1, 2, 4)))
+	require.True(s1.Equals(s1))
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin Set.All
+	st := immutable.New(1, 2, 3)
+	sum := 0
+	for e := range st.All() {
+		sum += e
+	}
+	require.Equal(6, sum)
+	// testdoc end
+
+	n := 0
+	for range st.All() {
+		n++
+		break
+	}
+	require.Equal(1, n)
+}
+
+func (s *Suite) TestToSlice() {
+	require := require.New(s.T())
+	// testdoc begin Set.ToSlice
+	st := immutable.New(1, 2, 3)
+	require.ElementsMatch([]int{1, 2, 3}, st.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Set.Union
+	s1 := immutable.New(1, 2, 3)
+	s2 := immutable.New(3, 4, 5)
+	require.True(s1.Union(s2).Equals(immutable.New(1, 2, 3, 4, 5)))
+	require.True(s1.Equals(immutable.New(1, 2, 3))) // s1 should be unchanged
+	require.True(s2.Equals(immutable.New(3, 4, 5))) // s2 should be unchanged
+	// testdoc end
+	require.True(s1.Union(immutable.New[int]()).Equals(s1))
+	require.True(immutable.New[int]().Union(s1).Equals(s1))
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin Set.Intersection
+	s1 := immutable.New(1, 2, 3, 4)
+	s2 := immutable.New(3, 4, 5, 6)
+	require.True(s1.Intersection(s2).Equals(immutable.New(3, 4)))
+	// testdoc end
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin Set.Difference
+	s1 := immutable.New(1, 2, 3, 4)
+	s2 := immutable.New(3, 4, 5, 6)
+	require.True(s1.Difference(s2).Equals(immutable.New(1, 2)))
+	require.True(s1.Equals(immutable.New(1, 2, 3, 4))) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestToBuilder() {
+	require := require.New(s.T())
+	// testdoc begin Set.ToBuilder
+	v1 := immutable.New(1, 2)
+	b := v1.ToBuilder()
+	b.Insert(3)
+	b.Remove(1)
+	v2 := b.Build()
+	require.True(v2.Equals(immutable.New(2, 3)))
+	require.True(v1.Equals(immutable.New(1, 2))) // v1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestNewBuilder() {
+	require := require.New(s.T())
+	// testdoc begin NewBuilder
+	b := immutable.NewBuilder[int]()
+	for i := range 1000 {
+		b.Insert(i)
+	}
+	st := b.Build()
+	require.Equal(1000, st.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestBuilderInsert() {
+	require := require.New(s.T())
+	// testdoc begin Builder.Insert
+	b := immutable.NewBuilder[string]()
+	b.Insert("a")
+	b.Insert("b", "a")
+	require.Equal(2, b.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestBuilderRemove() {
+	require := require.New(s.T())
+	// testdoc begin Builder.Remove
+	b := immutable.New(1, 2, 3).ToBuilder()
+	b.Remove(2, 4)
+	require.Equal(2, b.Len())
+	// testdoc end
+	b.Remove(1, 3)
+	require.Equal(0, b.Len())
+	b.Remove(1)
+	require.Equal(0, b.Len())
+	b.Insert(5)
+	require.True(b.Build().Equals(immutable.New(5)))
+}
+
+func (s *Suite) TestBuilderContains() {
+	require := require.New(s.T())
+	// testdoc begin Builder.Contains
+	b := immutable.NewBuilder[int]()
+	b.Insert(1)
+	require.True(b.Contains(1))
+	require.False(b.Contains(2))
+	// testdoc end
+}
+
+func (s *Suite) TestBuilderLen() {
+	require := require.New(s.T())
+	// testdoc begin Builder.Len
+	b := immutable.NewBuilder[int]()
+	b.Insert(1, 2, 2)
+	require.Equal(2, b.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestBuild() {
+	require := require.New(s.T())
+	// testdoc begin Builder.Build
+	b := immutable.NewBuilder[int]()
+	b.Insert(1, 2)
+	v1 := b.Build()
+	b.Insert(3) // does not affect v1
+	v2 := b.Build()
+	require.Equal(2, v1.Len())
+	require.Equal(3, v2.Len())
+	// testdoc end
+	require.False(v1.Contains(3))
+}
+
+// TestPersistence keeps every version of a randomly edited set and checks
+// that no later change leaks into an earlier version.
+func (s *Suite) TestPersistence() {
+	require := require.New(s.T())
+
+	r := rand.New(rand.NewPCG(13, 14))
+	current := immutable.New[int]()
+	reference := set.New[int]()
+	var versions []immutable.Set[int]
+	var snapshots []set.Set[int]
+	for i := range 3000 {
+		e := r.IntN(1000)
+		if r.IntN(3) == 0 {
+			current = current.Without(e)
+			reference.Remove(e)
+		} else {
+			current = current.With(e)
+			reference.Insert(e)
+		}
+		if i%100 == 0 {
+			versions = append(versions, current)
+			snapshots = append(snapshots, reference.Copy())
+		}
+	}
+	require.True(current.ToSet().Equals(reference))
+	for i, v := range versions {
+		require.True(v.ToSet().Equals(snapshots[i]), "version %d", i)
+		require.Equal(snapshots[i].Len(), v.Len())
+	}
+
+	// builders derived from a version must not modify it
+	for i, v := range versions {
+		b := v.ToBuilder()
+		for e := range 1000 {
+			if e%2 == 0 {
+				b.Insert(e)
+			} else {
+				b.Remove(e)
+			}
+		}
+		_ = b.Build()
+		require.True(v.ToSet().Equals(snapshots[i]), "version %d", i)
+	}
+}
+
+func BenchmarkWith(b *testing.B) {
+	st := immutable.New[int]()
+	for i := range 100000 {
+		st = st.With(i)
+	}
+	i := 0
+	for b.Loop() {
+		_ = st.With(100000 + i)
+		i++
+	}
+}
+
+func BenchmarkCopyInsert_Set(b *testing.B) {
+	st := set.New[int]()
+	for i := range 100000 {
+		st.Insert(i)
+	}
+	i := 0
+	for b.Loop() {
+		c := st.Copy()
+		c.Insert(100000 + i)
+		i++
+	}
+}
@@ -0,0 +1,407 @@
+// Package intervalset provides a set of values stored as merged half-open intervals.
+package intervalset
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Interval is the half-open range [Start, End).
+// An interval whose End is not after its Start is empty.
+type Interval[T any] struct {
+	Start T
+	End   T
+}
+
+// Number is the constraint for element types whose covered length can be measured.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Set is a set of values represented as sorted, disjoint, non-adjacent half-open intervals.
+// Overlapping or touching ranges are merged on insertion, so the set describes
+// which points are covered without enumerating them.
+//
+// A Set must be created with New, NewFunc or NewTime.
+type Set[T any] struct {
+	intervals []Interval[T]
+	cmp       func(a, b T) int
+}
+
+// New creates a new Set ordered by cmp.Compare and initializes it with the provided intervals.
+//
+// Example:
+//
+//	s1 := intervalset.New[int]()
+//	s2 := intervalset.New(intervalset.Interval[int]{5, 8}, intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{2, 4})
+//	require.True(s1.IsEmpty())
+//	require.Equal([]intervalset.Interval[int]{{1, 4}, {5, 8}}, s2.Intervals())
+func New[T cmp.Ordered](intervals ...Interval[T]) *Set[T] {
+	return NewFunc(cmp.Compare[T], intervals...)
+}
+
+// NewFunc creates a new Set ordered by the given comparison function
+// and initializes it with the provided intervals.
+// cmp follows the convention of slices.SortFunc.
+//
+// Example:
+//
+//	byLength := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+//	st := intervalset.NewFunc(byLength, intervalset.Interval[string]{"a", "ccc"})
+//	require.True(st.Contains("bb"))
+//	require.False(st.Contains("dddd"))
+func NewFunc[T any](cmp func(a, b T) int, intervals ...Interval[T]) *Set[T] {
+	s := &Set[T]{cmp: cmp}
+	for _, iv := range intervals {
+		s.Insert(iv.Start, iv.End)
+	}
+	return s
+}
+
+// Insert adds the range [start, end) to the set, merging it with overlapping or touching intervals.
+// An empty range is ignored.
+//
+// Example:
+//
+//	st := intervalset.New[int]()
+//	st.Insert(1, 3)
+//	st.Insert(5, 7)
+//	require.Equal(2, st.Len())
+//	st.Insert(3, 5) // bridges the two intervals
+//	require.Equal([]intervalset.Interval[int]{{1, 7}}, st.Intervals())
+func (s *Set[T]) Insert(start, end T) {
+	if s.cmp(start, end) >= 0 {
+		return
+	}
+	// [i, j) are the intervals that overlap or touch [start, end).
+	i := s.search(func(iv Interval[T]) bool { return s.cmp(iv.End, start) >= 0 })
+	j := s.search(func(iv Interval[T]) bool { return s.cmp(iv.Start, end) > 0 })
+	if i < j {
+		start = s.min(start, s.intervals[i].Start)
+		end = s.max(end, s.intervals[j-1].End)
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, Interval[T]{start, end})
+}
+
+// Remove deletes the range [start, end) from the set, splitting intervals that extend past it.
+// An empty range is ignored.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{0, 10})
+//	st.Remove(3, 5)
+//	require.Equal([]intervalset.Interval[int]{{0, 3}, {5, 10}}, st.Intervals())
+//	st.Remove(-5, 4)
+//	require.Equal([]intervalset.Interval[int]{{5, 10}}, st.Intervals())
+func (s *Set[T]) Remove(start, end T) {
+	if s.cmp(start, end) >= 0 {
+		return
+	}
+	// [i, j) are the intervals that overlap [start, end).
+	i := s.search(func(iv Interval[T]) bool { return s.cmp(iv.End, start) > 0 })
+	j := s.search(func(iv Interval[T]) bool { return s.cmp(iv.Start, end) >= 0 })
+	if i >= j {
+		return
+	}
+	pieces := make([]Interval[T], 0, 2)
+	if first := s.intervals[i]; s.cmp(first.Start, start) < 0 {
+		pieces = append(pieces, Interval[T]{first.Start, start})
+	}
+	if last := s.intervals[j-1]; s.cmp(last.End, end) > 0 {
+		pieces = append(pieces, Interval[T]{end, last.End})
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, pieces...)
+}
+
+// Contains checks if the point x is covered by the set.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{1, 3})
+//	require.True(st.Contains(1))
+//	require.True(st.Contains(2))
+//	require.False(st.Contains(3)) // intervals are half-open
+func (s *Set[T]) Contains(x T) bool {
+	i := s.search(func(iv Interval[T]) bool { return s.cmp(iv.End, x) > 0 })
+	return i < len(s.intervals) && s.cmp(s.intervals[i].Start, x) <= 0
+}
+
+// ContainsRange checks if every point of [start, end) is covered by the set.
+// An empty range is always covered.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{1, 5}, intervalset.Interval[int]{6, 9})
+//	require.True(st.ContainsRange(2, 5))
+//	require.False(st.ContainsRange(4, 7))
+func (s *Set[T]) ContainsRange(start, end T) bool {
+	if s.cmp(start, end) >= 0 {
+		return true
+	}
+	i := s.search(func(iv Interval[T]) bool { return s.cmp(iv.End, start) > 0 })
+	return i < len(s.intervals) && s.cmp(s.intervals[i].Start, start) <= 0 && s.cmp(s.intervals[i].End, end) >= 0
+}
+
+// Len returns the number of disjoint intervals in the set.
+//
+// Example:
+//
+//	st := intervalset.New[int]()
+//	require.Equal(0, st.Len())
+//	st.Insert(1, 3)
+//	st.Insert(2, 4)
+//	st.Insert(10, 20)
+//	require.Equal(2, st.Len())
+func (s *Set[T]) Len() int {
+	return len(s.intervals)
+}
+
+// IsEmpty checks if the set covers no points.
+//
+// Example:
+//
+//	st := intervalset.New[int]()
+//	require.True(st.IsEmpty())
+//	st.Insert(1, 2)
+//	require.False(st.IsEmpty())
+func (s *Set[T]) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// Equals checks if two sets cover the same points.
+//
+// Example:
+//
+//	s1 := intervalset.New(intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{3, 5})
+//	s2 := intervalset.New(intervalset.Interval[int]{1, 5})
+//	require.True(s1.Equals(s2))
+//	require.False(s1.Equals(intervalset.New(intervalset.Interval[int]{1, 4})))
+func (s *Set[T]) Equals(other *Set[T]) bool {
+	return slices.EqualFunc(s.intervals, other.intervals, func(a, b Interval[T]) bool {
+		return s.cmp(a.Start, b.Start) == 0 && s.cmp(a.End, b.End) == 0
+	})
+}
+
+// Bounds returns the smallest interval that covers the whole set.
+// The second result is false if the set is empty.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{5, 8}, intervalset.Interval[int]{1, 3})
+//	bounds, ok := st.Bounds()
+//	require.True(ok)
+//	require.Equal(intervalset.Interval[int]{1, 8}, bounds)
+func (s *Set[T]) Bounds() (Interval[T], bool) {
+	if len(s.intervals) == 0 {
+		return Interval[T]{}, false
+	}
+	return Interval[T]{s.intervals[0].Start, s.intervals[len(s.intervals)-1].End}, true
+}
+
+// Intervals returns the disjoint intervals of the set in ascending order.
+//
+// Example:
+//
+//	st := intervalset.New[int]()
+//	st.Insert(10, 12)
+//	st.Insert(1, 2)
+//	require.Equal([]intervalset.Interval[int]{{1, 2}, {10, 12}}, st.Intervals())
+func (s *Set[T]) Intervals() []Interval[T] {
+	return slices.Clone(s.intervals)
+}
+
+// All returns an iterator over the disjoint intervals of the set in ascending order.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{5, 9})
+//	total := 0
+//	for iv := range st.All() {
+//		total += iv.End - iv.Start
+//	}
+//	require.Equal(6, total)
+func (s *Set[T]) All() iter.Seq[Interval[T]] {
+	return func(yield func(Interval[T]) bool) {
+		for _, iv := range s.intervals {
+			if !yield(iv) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all intervals from the set.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{1, 3})
+//	st.Clear()
+//	require.True(st.IsEmpty())
+func (s *Set[T]) Clear() {
+	s.intervals = nil
+}
+
+// Copy creates a copy of the set.
+//
+// Example:
+//
+//	s1 := intervalset.New(intervalset.Interval[int]{1, 3})
+//	s2 := s1.Copy()
+//	s1.Insert(5, 6)
+//	require.Equal([]intervalset.Interval[int]{{1, 3}}, s2.Intervals())
+func (s *Set[T]) Copy() *Set[T] {
+	return &Set[T]{intervals: slices.Clone(s.intervals), cmp: s.cmp}
+}
+
+// Union creates a new set covering the points in either set.
+//
+// Example:
+//
+//	s1 := intervalset.New(intervalset.Interval[int]{1, 4}, intervalset.Interval[int]{8, 10})
+//	s2 := intervalset.New(intervalset.Interval[int]{3, 6}, intervalset.Interval[int]{10, 12})
+//	require.Equal([]intervalset.Interval[int]{{1, 6}, {8, 12}}, s1.Union(s2).Intervals())
+//	require.Equal([]intervalset.Interval[int]{{1, 4}, {8, 10}}, s1.Intervals()) // s1 should be unchanged
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := make([]Interval[T], 0, len(s.intervals)+len(other.intervals))
+	a, b := s.intervals, other.intervals
+	for len(a) > 0 || len(b) > 0 {
+		var next Interval[T]
+		if len(b) == 0 || (len(a) > 0 && s.cmp(a[0].Start, b[0].Start) <= 0) {
+			next, a = a[0], a[1:]
+		} else {
+			next, b = b[0], b[1:]
+		}
+		if n := len(result); n > 0 && s.cmp(next.Start, result[n-1].End) <= 0 {
+			result[n-1].End = s.max(result[n-1].End, next.End)
+		} else {
+			result = append(result, next)
+		}
+	}
+	return &Set[T]{intervals: result, cmp: s.cmp}
+}
+
+// Intersection creates a new set covering the points in both sets.
+//
+// Example:
+//
+//	s1 := intervalset.New(intervalset.Interval[int]{1, 5}, intervalset.Interval[int]{8, 12})
+//	s2 := intervalset.New(intervalset.Interval[int]{3, 10})
+//	require.Equal([]intervalset.Interval[int]{{3, 5}, {8, 10}}, s1.Intersection(s2).Intervals())
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	var result []Interval[T]
+	a, b := s.intervals, other.intervals
+	for len(a) > 0 && len(b) > 0 {
+		start, end := s.max(a[0].Start, b[0].Start), s.min(a[0].End, b[0].End)
+		if s.cmp(start, end) < 0 {
+			result = append(result, Interval[T]{start, end})
+		}
+		if s.cmp(a[0].End, b[0].End) < 0 {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return &Set[T]{intervals: result, cmp: s.cmp}
+}
+
+// Difference creates a new set covering the points in the current set but not in the other set.
+//
+// Example:
+//
+//	s1 := intervalset.New(intervalset.Interval[int]{0, 10})
+//	s2 := intervalset.New(intervalset.Interval[int]{2, 3}, intervalset.Interval[int]{5, 7})
+//	require.Equal([]intervalset.Interval[int]{{0, 2}, {3, 5}, {7, 10}}, s1.Difference(s2).Intervals())
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	var result []Interval[T]
+	b := other.intervals
+	for _, iv := range s.intervals {
+		for len(b) > 0 && s.cmp(b[0].End, iv.Start) <= 0 {
+			b = b[1:]
+		}
+		start := iv.Start
+		for _, cut := range b {
+			if s.cmp(cut.Start, iv.End) >= 0 {
+				break
+			}
+			if s.cmp(cut.Start, start) > 0 {
+				result = append(result, Interval[T]{start, cut.Start})
+			}
+			start = s.max(start, cut.End)
+		}
+		if s.cmp(start, iv.End) < 0 {
+			result = append(result, Interval[T]{start, iv.End})
+		}
+	}
+	return &Set[T]{intervals: result, cmp: s.cmp}
+}
+
+// Complement creates a new set covering the points of [lo, hi) that are not in the set.
+//
+// Example:
+//
+//	reserved := intervalset.New(intervalset.Interval[int]{0, 1024}, intervalset.Interval[int]{8080, 8081})
+//	free := reserved.Complement(0, 65536)
+//	require.Equal([]intervalset.Interval[int]{{1024, 8080}, {8081, 65536}}, free.Intervals())
+func (s *Set[T]) Complement(lo, hi T) *Set[T] {
+	return NewFunc(s.cmp, Interval[T]{lo, hi}).Difference(s)
+}
+
+// Gaps creates a new set covering the holes between the intervals of the set.
+// Points before the first interval and after the last one are not included.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{5, 6}, intervalset.Interval[int]{9, 10})
+//	require.Equal([]intervalset.Interval[int]{{3, 5}, {6, 9}}, st.Gaps().Intervals())
+func (s *Set[T]) Gaps() *Set[T] {
+	result := make([]Interval[T], 0, max(len(s.intervals)-1, 0))
+	for i := 1; i < len(s.intervals); i++ {
+		result = append(result, Interval[T]{s.intervals[i-1].End, s.intervals[i].Start})
+	}
+	return &Set[T]{intervals: result, cmp: s.cmp}
+}
+
+// Length returns the total length covered by the set.
+//
+// Example:
+//
+//	st := intervalset.New(intervalset.Interval[int]{1, 4}, intervalset.Interval[int]{3, 6}, intervalset.Interval[int]{10, 12})
+//	require.Equal(7, intervalset.Length(st))
+func Length[T Number](s *Set[T]) T {
+	var total T
+	for _, iv := range s.intervals {
+		total += iv.End - iv.Start
+	}
+	return total
+}
+
+// search returns the index of the first interval satisfying pred,
+// which must be false for a prefix of the intervals and true for the rest.
+func (s *Set[T]) search(pred func(Interval[T]) bool) int {
+	i, _ := slices.BinarySearchFunc(s.intervals, true, func(iv Interval[T], _ bool) int {
+		if pred(iv) {
+			return 1
+		}
+		return -1
+	})
+	return i
+}
+
+func (s *Set[T]) min(a, b T) T {
+	if s.cmp(b, a) < 0 {
+		return b
+	}
+	return a
+}
+
+func (s *Set[T]) max(a, b T) T {
+	if s.cmp(b, a) > 0 {
+		return b
+	}
+	return a
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
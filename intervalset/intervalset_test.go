@@ -0,0 +1,330 @@
+package intervalset_test
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/intervalset"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	s1 := intervalset.New[int]()
+	s2 := intervalset.New(intervalset.Interval[int]{5, 8}, intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{2, 4})
+	require.True(s1.IsEmpty())
+	require.Equal([]intervalset.Interval[int]{{1, 4}, {5, 8}}, s2.Intervals())
+	// testdoc end
+}
+
+func (s *Suite) TestNewFunc() {
+	require := require.New(s.T())
+	// testdoc begin NewFunc
+	byLength := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	st := intervalset.NewFunc(byLength, intervalset.Interval[string]{"a", "ccc"})
+	require.True(st.Contains("bb"))
+	require.False(st.Contains("dddd"))
+	// testdoc end
+}
+
+func (s *Suite) TestInsert() {
+	require := require.New(s.T())
+	// testdoc begin Set.Insert
+	st := intervalset.New[int]()
+	st.Insert(1, 3)
+	st.Insert(5, 7)
+	require.Equal(2, st.Len())
+	st.Insert(3, 5) // bridges the two intervals
+	require.Equal([]intervalset.Interval[int]{{1, 7}}, st.Intervals())
+	// testdoc end
+
+	st.Insert(4, 4)
+	st.Insert(9, 8)
+	require.Equal([]intervalset.Interval[int]{{1, 7}}, st.Intervals())
+	st.Insert(-10, -5)
+	st.Insert(20, 30)
+	st.Insert(2, 3)
+	require.Equal([]intervalset.Interval[int]{{-10, -5}, {1, 7}, {20, 30}}, st.Intervals())
+	st.Insert(-6, 25)
+	require.Equal([]intervalset.Interval[int]{{-10, 30}}, st.Intervals())
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin Set.Remove
+	st := intervalset.New(intervalset.Interval[int]{0, 10})
+	st.Remove(3, 5)
+	require.Equal([]intervalset.Interval[int]{{0, 3}, {5, 10}}, st.Intervals())
+	st.Remove(-5, 4)
+	require.Equal([]intervalset.Interval[int]{{5, 10}}, st.Intervals())
+	// testdoc end
+
+	st.Remove(10, 20)
+	st.Remove(7, 7)
+	require.Equal([]intervalset.Interval[int]{{5, 10}}, st.Intervals())
+	st.Remove(5, 10)
+	require.True(st.IsEmpty())
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Set.Contains
+	st := intervalset.New(intervalset.Interval[int]{1, 3})
+	require.True(st.Contains(1))
+	require.True(st.Contains(2))
+	require.False(st.Contains(3)) // intervals are half-open
+	// testdoc end
+	require.False(st.Contains(0))
+	require.False(intervalset.New[int]().Contains(0))
+}
+
+func (s *Suite) TestContainsRange() {
+	require := require.New(s.T())
+	// testdoc begin Set.ContainsRange
+	st := intervalset.New(intervalset.Interval[int]{1, 5}, intervalset.Interval[int]{6, 9})
+	require.True(st.ContainsRange(2, 5))
+	require.False(st.ContainsRange(4, 7))
+	// testdoc end
+	require.True(st.ContainsRange(100, 100))
+	require.False(st.ContainsRange(0, 2))
+	require.False(st.ContainsRange(9, 10))
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin Set.Len
+	st := intervalset.New[int]()
+	require.Equal(0, st.Len())
+	st.Insert(1, 3)
+	st.Insert(2, 4)
+	st.Insert(10, 20)
+	require.Equal(2, st.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsEmpty
+	st := intervalset.New[int]()
+	require.True(st.IsEmpty())
+	st.Insert(1, 2)
+	require.False(st.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin Set.Equals
+	s1 := intervalset.New(intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{3, 5})
+	s2 := intervalset.New(intervalset.Interval[int]{1, 5})
+	require.True(s1.Equals(s2))
+	require.False(s1.Equals(intervalset.New(intervalset.Interval[int]{1, 4})))
+	// testdoc end
+}
+
+func (s *Suite) TestBounds() {
+	require := require.New(s.T())
+	// testdoc begin Set.Bounds
+	st := intervalset.New(intervalset.Interval[int]{5, 8}, intervalset.Interval[int]{1, 3})
+	bounds, ok := st.Bounds()
+	require.True(ok)
+	require.Equal(intervalset.Interval[int]{1, 8}, bounds)
+	// testdoc end
+	_, ok = intervalset.New[int]().Bounds()
+	require.False(ok)
+}
+
+func (s *Suite) TestIntervals() {
+	require := require.New(s.T())
+	// testdoc begin Set.Intervals
+	st := intervalset.New[int]()
+	st.Insert(10, 12)
+	st.Insert(1, 2)
+	require.Equal([]intervalset.Interval[int]{{1, 2}, {10, 12}}, st.Intervals())
+	// testdoc end
+
+	ivs := st.Intervals()
+	ivs[0].End = 100
+	require.Equal([]intervalset.Interval[int]{{1, 2}, {10, 12}}, st.Intervals())
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin Set.All
+	st := intervalset.New(intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{5, 9})
+	total := 0
+	for iv := range st.All() {
+		total += iv.End - iv.Start
+	}
+	require.Equal(6, total)
+	// testdoc end
+	n := 0
+	for range st.All() {
+		n++
+		break
+	}
+	require.Equal(1, n)
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin Set.Clear
+	st := intervalset.New(intervalset.Interval[int]{1, 3})
+	st.Clear()
+	require.True(st.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin Set.Copy
+	s1 := intervalset.New(intervalset.Interval[int]{1, 3})
+	s2 := s1.Copy()
+	s1.Insert(5, 6)
+	require.Equal([]intervalset.Interval[int]{{1, 3}}, s2.Intervals())
+	// testdoc end
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Set.Union
+	s1 := intervalset.New(intervalset.Interval[int]{1, 4}, intervalset.Interval[int]{8, 10})
+	s2 := intervalset.New(intervalset.Interval[int]{3, 6}, intervalset.Interval[int]{10, 12})
+	require.Equal([]intervalset.Interval[int]{{1, 6}, {8, 12}}, s1.Union(s2).Intervals())
+	require.Equal([]intervalset.Interval[int]{{1, 4}, {8, 10}}, s1.Intervals()) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin Set.Intersection
+	s1 := intervalset.New(intervalset.Interval[int]{1, 5}, intervalset.Interval[int]{8, 12})
+	s2 := intervalset.New(intervalset.Interval[int]{3, 10})
+	require.Equal([]intervalset.Interval[int]{{3, 5}, {8, 10}}, s1.Intersection(s2).Intervals())
+	// testdoc end
+	require.True(s1.Intersection(intervalset.New(intervalset.Interval[int]{5, 8})).IsEmpty())
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin Set.Difference
+	s1 := intervalset.New(intervalset.Interval[int]{0, 10})
+	s2 := intervalset.New(intervalset.Interval[int]{2, 3}, intervalset.Interval[int]{5, 7})
+	require.Equal([]intervalset.Interval[int]{{0, 2}, {3, 5}, {7, 10}}, s1.Difference(s2).Intervals())
+	// testdoc end
+	require.True(s2.Difference(s1).IsEmpty())
+}
+
+func (s *Suite) TestComplement() {
+	require := require.New(s.T())
+	// testdoc begin Set.Complement
+	reserved := intervalset.New(intervalset.Interval[int]{0, 1024}, intervalset.Interval[int]{8080, 8081})
+	free := reserved.Complement(0, 65536)
+	require.Equal([]intervalset.Interval[int]{{1024, 8080}, {8081, 65536}}, free.Intervals())
+	// testdoc end
+	require.Equal([]intervalset.Interval[int]{{2000, 3000}}, reserved.Complement(2000, 3000).Intervals())
+	require.True(reserved.Complement(10, 20).IsEmpty())
+}
+
+func (s *Suite) TestGaps() {
+	require := require.New(s.T())
+	// testdoc begin Set.Gaps
+	st := intervalset.New(intervalset.Interval[int]{1, 3}, intervalset.Interval[int]{5, 6}, intervalset.Interval[int]{9, 10})
+	require.Equal([]intervalset.Interval[int]{{3, 5}, {6, 9}}, st.Gaps().Intervals())
+	// testdoc end
+	require.True(intervalset.New[int]().Gaps().IsEmpty())
+}
+
+func (s *Suite) TestLength() {
+	require := require.New(s.T())
+	// testdoc begin Length
+	st := intervalset.New(intervalset.Interval[int]{1, 4}, intervalset.Interval[int]{3, 6}, intervalset.Interval[int]{10, 12})
+	require.Equal(7, intervalset.Length(st))
+	// testdoc end
+	require.InDelta(1.5, intervalset.Length(intervalset.New(intervalset.Interval[float64]{0.5, 2})), 1e-9)
+}
+
+// TestRandomized compares the set against a point-by-point model over a small domain.
+func (s *Suite) TestRandomized() {
+	require := require.New(s.T())
+	const domain = 64
+	r := rand.New(rand.NewPCG(3, 4))
+	randomRange := func() (int, int) {
+		a, b := r.IntN(domain), r.IntN(domain)
+		return min(a, b), max(a, b) + r.IntN(3)
+	}
+	points := func(st *intervalset.Set[int]) [domain + 3]bool {
+		var p [domain + 3]bool
+		for i := range p {
+			p[i] = st.Contains(i)
+		}
+		return p
+	}
+	randomSet := func() (*intervalset.Set[int], [domain + 3]bool) {
+		st := intervalset.New[int]()
+		var model [domain + 3]bool
+		for range 8 {
+			lo, hi := randomRange()
+			insert := r.IntN(3) > 0
+			if insert {
+				st.Insert(lo, hi)
+			} else {
+				st.Remove(lo, hi)
+			}
+			for i := lo; i < hi; i++ {
+				model[i] = insert
+			}
+		}
+		return st, model
+	}
+	for range 500 {
+		a, ma := randomSet()
+		b, mb := randomSet()
+		require.Equal(ma, points(a))
+
+		// intervals stay sorted, non-empty and non-adjacent
+		ivs := a.Intervals()
+		for i, iv := range ivs {
+			require.Less(iv.Start, iv.End)
+			if i > 0 {
+				require.Less(ivs[i-1].End, iv.Start)
+			}
+		}
+
+		var union, inter, diff, comp [domain + 3]bool
+		length := 0
+		for i := range ma {
+			union[i] = ma[i] || mb[i]
+			inter[i] = ma[i] && mb[i]
+			diff[i] = ma[i] && !mb[i]
+			comp[i] = !ma[i] && i >= 10 && i < 50
+			if ma[i] {
+				length++
+			}
+		}
+		require.Equal(union, points(a.Union(b)))
+		require.Equal(inter, points(a.Intersection(b)))
+		require.Equal(diff, points(a.Difference(b)))
+		require.Equal(comp, points(a.Complement(10, 50)))
+		require.Equal(length, intervalset.Length(a))
+
+		lo, hi := randomRange()
+		covered := true
+		for i := lo; i < hi; i++ {
+			covered = covered && ma[i]
+		}
+		require.Equal(covered, a.ContainsRange(lo, hi))
+	}
+}
@@ -0,0 +1,44 @@
+package intervalset
+
+import "time"
+
+// NewTime creates a new Set of time windows ordered chronologically
+// and initializes it with the provided intervals.
+//
+// Example:
+//
+//	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) }
+//	busy := intervalset.NewTime(
+//		intervalset.Interval[time.Time]{at(9), at(10)},
+//		intervalset.Interval[time.Time]{at(10), at(12)},
+//		intervalset.Interval[time.Time]{at(14), at(15)},
+//	)
+//	require.Equal(2, busy.Len())
+//	require.True(busy.Contains(at(11)))
+//
+//	free := busy.Complement(at(9), at(17))
+//	require.Equal([]intervalset.Interval[time.Time]{{at(12), at(14)}, {at(15), at(17)}}, free.Intervals())
+func NewTime(intervals ...Interval[time.Time]) *Set[time.Time] {
+	return NewFunc(time.Time.Compare, intervals...)
+}
+
+// Duration returns the total time covered by a set of time windows.
+//
+// Example:
+//
+//	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) }
+//	windows := intervalset.NewTime(
+//		intervalset.Interval[time.Time]{at(9), at(11)},
+//		intervalset.Interval[time.Time]{at(10), at(12)},
+//		intervalset.Interval[time.Time]{at(13), at(14)},
+//	)
+//	require.Equal(4*time.Hour, intervalset.Duration(windows))
+func Duration(s *Set[time.Time]) time.Duration {
+	var total time.Duration
+	for _, iv := range s.intervals {
+		total += iv.End.Sub(iv.Start)
+	}
+	return total
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,55 @@
+package intervalset_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/intervalset"
+)
+
+type TimeSuite struct {
+	suite.Suite
+}
+
+func TestTimeSuite(t *testing.T) {
+	suite.Run(t, new(TimeSuite))
+}
+
+func (s *TimeSuite) TestNewTime() {
+	require := require.New(s.T())
+	// testdoc begin NewTime
+	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) }
+	busy := intervalset.NewTime(
+		intervalset.Interval[time.Time]{at(9), at(10)},
+		intervalset.Interval[time.Time]{at(10), at(12)},
+		intervalset.Interval[time.Time]{at(14), at(15)},
+	)
+	require.Equal(2, busy.Len())
+	require.True(busy.Contains(at(11)))
+
+	free := busy.Complement(at(9), at(17))
+	require.Equal([]intervalset.Interval[time.Time]{{at(12), at(14)}, {at(15), at(17)}}, free.Intervals())
+	// testdoc end
+
+	// instants are compared chronologically regardless of location
+	tokyo := time.FixedZone("JST", 9*60*60)
+	busy.Insert(time.Date(2024, 1, 1, 21, 0, 0, 0, tokyo), time.Date(2024, 1, 1, 23, 0, 0, 0, tokyo)) // 12:00-14:00 UTC
+	require.Equal(1, busy.Len())
+}
+
+func (s *TimeSuite) TestDuration() {
+	require := require.New(s.T())
+	// testdoc begin Duration
+	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) }
+	windows := intervalset.NewTime(
+		intervalset.Interval[time.Time]{at(9), at(11)},
+		intervalset.Interval[time.Time]{at(10), at(12)},
+		intervalset.Interval[time.Time]{at(13), at(14)},
+	)
+	require.Equal(4*time.Hour, intervalset.Duration(windows))
+	// testdoc end
+	require.Equal(time.Duration(0), intervalset.Duration(intervalset.NewTime()))
+}
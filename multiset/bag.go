@@ -0,0 +1,311 @@
+// Package multiset provides a bag (multiset) that counts occurrences of elements.
+package multiset
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+// Bag is a generic multiset that stores a positive count for each distinct element.
+type Bag[T comparable] struct {
+	counts map[T]int
+}
+
+// Entry is an element of a Bag together with its count.
+type Entry[T comparable] struct {
+	Element T
+	Count   int
+}
+
+// New creates a new Bag and adds one occurrence for each of the provided elements.
+//
+// Example:
+//
+//	b1 := multiset.New[string]()
+//	b2 := multiset.New("a", "b", "a")
+//	require.True(b1.IsEmpty())
+//	require.Equal(2, b2.Count("a"))
+//	require.Equal(1, b2.Count("b"))
+func New[T comparable](elements ...T) Bag[T] {
+	b := Bag[T]{
+		counts: make(map[T]int),
+	}
+	for _, e := range elements {
+		b.Add(e, 1)
+	}
+	return b
+}
+
+// Add adds n occurrences of element to the bag. Non-positive n is ignored.
+//
+// Example:
+//
+//	b := multiset.New[string]()
+//	b.Add("a", 2)
+//	b.Add("a", 3)
+//	require.Equal(5, b.Count("a"))
+func (b *Bag[T]) Add(element T, n int) {
+	if n <= 0 {
+		return
+	}
+	b.counts[element] += n
+}
+
+// Remove removes up to n occurrences of element from the bag.
+// The count never drops below zero, and elements reaching zero are removed. Non-positive n is ignored.
+//
+// Example:
+//
+//	b := multiset.New[string]()
+//	b.Add("a", 5)
+//	b.Remove("a", 2)
+//	require.Equal(3, b.Count("a"))
+//	b.Remove("a", 10) // clamped at zero
+//	require.Equal(0, b.Count("a"))
+//	require.False(b.Contains("a"))
+func (b *Bag[T]) Remove(element T, n int) {
+	if n <= 0 {
+		return
+	}
+	if b.counts[element] <= n {
+		delete(b.counts, element)
+		return
+	}
+	b.counts[element] -= n
+}
+
+// Count returns the number of occurrences of element in the bag.
+//
+// Example:
+//
+//	b := multiset.New(1, 1, 2)
+//	require.Equal(2, b.Count(1))
+//	require.Equal(0, b.Count(3))
+func (b Bag[T]) Count(element T) int {
+	return b.counts[element]
+}
+
+// Contains checks if the bag holds at least one occurrence of element.
+//
+// Example:
+//
+//	b := multiset.New(1, 1)
+//	require.True(b.Contains(1))
+//	require.False(b.Contains(2))
+func (b Bag[T]) Contains(element T) bool {
+	return b.counts[element] > 0
+}
+
+// Len returns the total number of occurrences in the bag.
+//
+// Example:
+//
+//	b := multiset.New("a", "a", "b")
+//	require.Equal(3, b.Len())
+func (b Bag[T]) Len() int {
+	n := 0
+	for _, c := range b.counts {
+		n += c
+	}
+	return n
+}
+
+// DistinctLen returns the number of distinct elements in the bag.
+//
+// Example:
+//
+//	b := multiset.New("a", "a", "b")
+//	require.Equal(2, b.DistinctLen())
+func (b Bag[T]) DistinctLen() int {
+	return len(b.counts)
+}
+
+// IsEmpty checks if the bag is empty.
+//
+// Example:
+//
+//	b := multiset.New[int]()
+//	require.True(b.IsEmpty())
+//	b.Add(1, 1)
+//	require.False(b.IsEmpty())
+func (b Bag[T]) IsEmpty() bool {
+	return len(b.counts) == 0
+}
+
+// Equals checks if two bags hold the same elements with the same counts.
+//
+// Example:
+//
+//	b1 := multiset.New(1, 1, 2)
+//	require.True(b1.Equals(multiset.New(2, 1, 1)))
+//	require.False(b1.Equals(multiset.New(1, 2)))
+func (b Bag[T]) Equals(other Bag[T]) bool {
+	if len(b.counts) != len(other.counts) {
+		return false
+	}
+	for e, c := range b.counts {
+		if other.counts[e] != c {
+			return false
+		}
+	}
+	return true
+}
+
+// Distinct returns the set of distinct elements in the bag.
+//
+// Example:
+//
+//	b := multiset.New("a", "b", "a")
+//	require.True(b.Distinct().Equals(set.New("a", "b")))
+func (b Bag[T]) Distinct() set.Set[T] {
+	s := set.New[T]()
+	for e := range b.counts {
+		s.Insert(e)
+	}
+	return s
+}
+
+// All returns an iterator over the distinct elements of the bag and their counts.
+// The iteration order is not specified.
+//
+// Example:
+//
+//	b := multiset.New("a", "b", "a")
+//	counts := map[string]int{}
+//	for e, c := range b.All() {
+//		counts[e] = c
+//	}
+//	require.Equal(map[string]int{"a": 2, "b": 1}, counts)
+func (b Bag[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for e, c := range b.counts {
+			if !yield(e, c) {
+				return
+			}
+		}
+	}
+}
+
+// MostCommon returns the k elements with the highest counts, ordered from most to least common.
+// If k is negative or exceeds the number of distinct elements, all elements are returned.
+// The order of elements with equal counts is not specified.
+//
+// Example:
+//
+//	b := multiset.New("a", "b", "b", "c", "c", "c")
+//	require.Equal([]multiset.Entry[string]{
+//		{Element: "c", Count: 3},
+//		{Element: "b", Count: 2},
+//	}, b.MostCommon(2))
+func (b Bag[T]) MostCommon(k int) []Entry[T] {
+	entries := make([]Entry[T], 0, len(b.counts))
+	for e, c := range b.counts {
+		entries = append(entries, Entry[T]{Element: e, Count: c})
+	}
+	slices.SortFunc(entries, func(x, y Entry[T]) int {
+		return cmp.Compare(y.Count, x.Count)
+	})
+	if k >= 0 && k < len(entries) {
+		entries = entries[:k]
+	}
+	return entries
+}
+
+// Clear removes all elements from the bag.
+//
+// Example:
+//
+//	b := multiset.New(1, 2)
+//	b.Clear()
+//	require.True(b.IsEmpty())
+func (b *Bag[T]) Clear() {
+	b.counts = make(map[T]int)
+}
+
+// Copy creates a shallow copy of the bag.
+//
+// Example:
+//
+//	b1 := multiset.New(1, 1)
+//	b2 := b1.Copy()
+//	b1.Add(1, 1)
+//	require.Equal(2, b2.Count(1))
+func (b *Bag[T]) Copy() Bag[T] {
+	newBag := New[T]()
+	for e, c := range b.counts {
+		newBag.counts[e] = c
+	}
+	return newBag
+}
+
+// Sum creates a new bag whose counts are the sums of the counts in both bags.
+//
+// Example:
+//
+//	b1 := multiset.New("a", "a", "b")
+//	b2 := multiset.New("a", "c")
+//	sum := b1.Sum(b2)
+//	require.True(sum.Equals(multiset.New("a", "a", "a", "b", "c")))
+//	require.True(b1.Equals(multiset.New("a", "a", "b"))) // b1 should be unchanged
+func (b Bag[T]) Sum(other Bag[T]) Bag[T] {
+	result := b.Copy()
+	for e, c := range other.counts {
+		result.counts[e] += c
+	}
+	return result
+}
+
+// Union creates a new bag whose counts are the maximum of the counts in both bags.
+//
+// Example:
+//
+//	b1 := multiset.New("a", "a", "b")
+//	b2 := multiset.New("a", "b", "b", "b", "c")
+//	require.True(b1.Union(b2).Equals(multiset.New("a", "a", "b", "b", "b", "c")))
+func (b Bag[T]) Union(other Bag[T]) Bag[T] {
+	result := b.Copy()
+	for e, c := range other.counts {
+		result.counts[e] = max(result.counts[e], c)
+	}
+	return result
+}
+
+// Intersection creates a new bag whose counts are the minimum of the counts in both bags.
+//
+// Example:
+//
+//	b1 := multiset.New("a", "a", "b")
+//	b2 := multiset.New("a", "b", "b", "b", "c")
+//	require.True(b1.Intersection(b2).Equals(multiset.New("a", "b")))
+func (b Bag[T]) Intersection(other Bag[T]) Bag[T] {
+	result := New[T]()
+	for e, c := range b.counts {
+		if oc := other.counts[e]; oc > 0 {
+			result.counts[e] = min(c, oc)
+		}
+	}
+	return result
+}
+
+// Difference creates a new bag whose counts are the counts of the current bag
+// minus the counts of the other bag, clamped at zero.
+//
+// Example:
+//
+//	b1 := multiset.New("a", "a", "a", "b")
+//	b2 := multiset.New("a", "b", "b", "c")
+//	require.True(b1.Difference(b2).Equals(multiset.New("a", "a")))
+func (b Bag[T]) Difference(other Bag[T]) Bag[T] {
+	result := New[T]()
+	for e, c := range b.counts {
+		if d := c - other.counts[e]; d > 0 {
+			result.counts[e] = d
+		}
+	}
+	return result
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,215 @@
+package multiset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/multiset"
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	b1 := multiset.New[string]()
+	b2 := multiset.New("a", "b", "a")
+	require.True(b1.IsEmpty())
+	require.Equal(2, b2.Count("a"))
+	require.Equal(1, b2.Count("b"))
+	// testdoc end
+}
+
+func (s *Suite) TestAdd() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Add
+	b := multiset.New[string]()
+	b.Add("a", 2)
+	b.Add("a", 3)
+	require.Equal(5, b.Count("a"))
+	// testdoc end
+	b.Add("b", 0)
+	b.Add("b", -1)
+	require.False(b.Contains("b"))
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Remove
+	b := multiset.New[string]()
+	b.Add("a", 5)
+	b.Remove("a", 2)
+	require.Equal(3, b.Count("a"))
+	b.Remove("a", 10) // clamped at zero
+	require.Equal(0, b.Count("a"))
+	require.False(b.Contains("a"))
+	// testdoc end
+	b.Add("b", 1)
+	b.Remove("b", 0)
+	b.Remove("b", -3)
+	require.Equal(1, b.Count("b"))
+	b.Remove("missing", 1)
+	require.Equal(1, b.DistinctLen())
+}
+
+func (s *Suite) TestCount() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Count
+	b := multiset.New(1, 1, 2)
+	require.Equal(2, b.Count(1))
+	require.Equal(0, b.Count(3))
+	// testdoc end
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Contains
+	b := multiset.New(1, 1)
+	require.True(b.Contains(1))
+	require.False(b.Contains(2))
+	// testdoc end
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Len
+	b := multiset.New("a", "a", "b")
+	require.Equal(3, b.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestDistinctLen() {
+	require := require.New(s.T())
+	// testdoc begin Bag.DistinctLen
+	b := multiset.New("a", "a", "b")
+	require.Equal(2, b.DistinctLen())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Bag.IsEmpty
+	b := multiset.New[int]()
+	require.True(b.IsEmpty())
+	b.Add(1, 1)
+	require.False(b.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Equals
+	b1 := multiset.New(1, 1, 2)
+	require.True(b1.Equals(multiset.New(2, 1, 1)))
+	require.False(b1.Equals(multiset.New(1, 2)))
+	// testdoc end
+	require.False(b1.Equals(multiset.New(1, 1, 3)))
+}
+
+func (s *Suite) TestDistinct() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Distinct
+	b := multiset.New("a", "b", "a")
+	require.True(b.Distinct().Equals(set.New("a", "b")))
+	// testdoc end
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin Bag.All
+	b := multiset.New("a", "b", "a")
+	counts := map[string]int{}
+	for e, c := range b.All() {
+		counts[e] = c
+	}
+	require.Equal(map[string]int{"a": 2, "b": 1}, counts)
+	// testdoc end
+
+	n := 0
+	for range b.All() {
+		n++
+		break
+	}
+	require.Equal(1, n)
+}
+
+func (s *Suite) TestMostCommon() {
+	require := require.New(s.T())
+	// testdoc begin Bag.MostCommon
+	b := multiset.New("a", "b", "b", "c", "c", "c")
+	require.Equal([]multiset.Entry[string]{
+		{Element: "c", Count: 3},
+		{Element: "b", Count: 2},
+	}, b.MostCommon(2))
+	// testdoc end
+	require.Len(b.MostCommon(10), 3)
+	require.Len(b.MostCommon(-1), 3)
+	require.Empty(b.MostCommon(0))
+	require.Empty(multiset.New[int]().MostCommon(3))
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Clear
+	b := multiset.New(1, 2)
+	b.Clear()
+	require.True(b.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Copy
+	b1 := multiset.New(1, 1)
+	b2 := b1.Copy()
+	b1.Add(1, 1)
+	require.Equal(2, b2.Count(1))
+	// testdoc end
+}
+
+func (s *Suite) TestSum() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Sum
+	b1 := multiset.New("a", "a", "b")
+	b2 := multiset.New("a", "c")
+	sum := b1.Sum(b2)
+	require.True(sum.Equals(multiset.New("a", "a", "a", "b", "c")))
+	require.True(b1.Equals(multiset.New("a", "a", "b"))) // b1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Union
+	b1 := multiset.New("a", "a", "b")
+	b2 := multiset.New("a", "b", "b", "b", "c")
+	require.True(b1.Union(b2).Equals(multiset.New("a", "a", "b", "b", "b", "c")))
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Intersection
+	b1 := multiset.New("a", "a", "b")
+	b2 := multiset.New("a", "b", "b", "b", "c")
+	require.True(b1.Intersection(b2).Equals(multiset.New("a", "b")))
+	// testdoc end
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin Bag.Difference
+	b1 := multiset.New("a", "a", "a", "b")
+	b2 := multiset.New("a", "b", "b", "c")
+	require.True(b1.Difference(b2).Equals(multiset.New("a", "a")))
+	// testdoc end
+}
@@ -0,0 +1,285 @@
+// Package orderedset provides a set that remembers the order in which elements were first inserted.
+package orderedset
+
+import "iter"
+
+// entry is a node of the doubly linked list that records insertion order.
+type entry[T comparable] struct {
+	value T
+	prev  *entry[T]
+	next  *entry[T]
+}
+
+// Set is a generic set that iterates in first-insertion order.
+// It combines a map for O(1) lookups with a doubly linked list for O(1) ordered removals.
+type Set[T comparable] struct {
+	data map[T]*entry[T]
+	// root is a sentinel: root.next is the oldest entry and root.prev is the newest.
+	root *entry[T]
+}
+
+// New creates a new Set and initializes it with the provided elements in order.
+//
+// Example:
+//
+//	s1 := orderedset.New[int]()
+//	s2 := orderedset.New(3, 1, 2, 1)
+//	require.True(s1.IsEmpty())
+//	require.Equal([]int{3, 1, 2}, s2.ToSlice())
+func New[T comparable](elements ...T) Set[T] {
+	root := &entry[T]{}
+	root.prev, root.next = root, root
+	s := Set[T]{
+		data: make(map[T]*entry[T]),
+		root: root,
+	}
+	for _, e := range elements {
+		s.Insert(e)
+	}
+	return s
+}
+
+// Insert appends one or more elements to the set.
+// Elements already in the set keep their original position.
+//
+// Example:
+//
+//	st := orderedset.New[string]()
+//	st.Insert("b")
+//	st.Insert("a", "c", "b")
+//	require.Equal([]string{"b", "a", "c"}, st.ToSlice())
+func (s *Set[T]) Insert(elements ...T) {
+	for _, element := range elements {
+		if _, exists := s.data[element]; exists {
+			continue
+		}
+		e := &entry[T]{value: element, prev: s.root.prev, next: s.root}
+		s.root.prev.next = e
+		s.root.prev = e
+		s.data[element] = e
+	}
+}
+
+// Remove deletes one or more elements from the set.
+//
+// Example:
+//
+//	st := orderedset.New(1, 2, 3, 4)
+//	st.Remove(2)
+//	st.Remove(4, 5)
+//	require.Equal([]int{1, 3}, st.ToSlice())
+func (s *Set[T]) Remove(elements ...T) {
+	for _, element := range elements {
+		e, exists := s.data[element]
+		if !exists {
+			continue
+		}
+		e.prev.next = e.next
+		e.next.prev = e.prev
+		delete(s.data, element)
+	}
+}
+
+// Contains checks if the set contains a specific element.
+//
+// Example:
+//
+//	st := orderedset.New(1, 2, 3)
+//	require.True(st.Contains(1))
+//	require.False(st.Contains(4))
+func (s Set[T]) Contains(element T) bool {
+	_, exists := s.data[element]
+	return exists
+}
+
+// Len returns the number of elements in the set.
+//
+// Example:
+//
+//	st := orderedset.New[int]()
+//	require.Equal(0, st.Len())
+//	st.Insert(1, 1, 2)
+//	require.Equal(2, st.Len())
+func (s Set[T]) Len() int {
+	return len(s.data)
+}
+
+// Equals checks if two sets contain the same elements, regardless of order.
+//
+// Example:
+//
+//	s1 := orderedset.New(1, 2, 3)
+//	require.True(s1.Equals(orderedset.New(3, 2, 1)))
+//	require.False(s1.Equals(orderedset.New(1, 2)))
+func (s Set[T]) Equals(other Set[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for element := range s.data {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty checks if the set is empty.
+//
+// Example:
+//
+//	st := orderedset.New[int]()
+//	require.True(st.IsEmpty())
+//	st.Insert(1)
+//	require.False(st.IsEmpty())
+func (s Set[T]) IsEmpty() bool {
+	return len(s.data) == 0
+}
+
+// All returns an iterator over the elements of the set in insertion order.
+// Removing the element currently being visited is safe during the iteration.
+//
+// Example:
+//
+//	st := orderedset.New("c", "a", "b")
+//	var got []string
+//	for e := range st.All() {
+//		got = append(got, e)
+//	}
+//	require.Equal([]string{"c", "a", "b"}, got)
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s.root == nil {
+			return
+		}
+		for e := s.root.next; e != s.root; e = e.next {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice converts the set to a slice of its elements in insertion order.
+//
+// Example:
+//
+//	st := orderedset.New(5, 3, 9)
+//	require.Equal([]int{5, 3, 9}, st.ToSlice())
+func (s Set[T]) ToSlice() []T {
+	elements := make([]T, 0, len(s.data))
+	for element := range s.All() {
+		elements = append(elements, element)
+	}
+	return elements
+}
+
+// Clear removes all elements from the set.
+//
+// Example:
+//
+//	st := orderedset.New(1, 2)
+//	st.Clear()
+//	require.True(st.IsEmpty())
+func (s *Set[T]) Clear() {
+	*s = New[T]()
+}
+
+// Copy creates a shallow copy of the set preserving the insertion order.
+//
+// Example:
+//
+//	s1 := orderedset.New(3, 1, 2)
+//	s2 := s1.Copy()
+//	s1.Insert(4)
+//	require.Equal([]int{3, 1, 2}, s2.ToSlice())
+func (s *Set[T]) Copy() Set[T] {
+	newSet := New[T]()
+	for e := range s.All() {
+		newSet.Insert(e)
+	}
+	return newSet
+}
+
+// Merge appends the elements of another set that are not yet present, in the other set's order.
+//
+// Example:
+//
+//	s1 := orderedset.New(3, 1)
+//	s1.Merge(orderedset.New(5, 1, 4))
+//	require.Equal([]int{3, 1, 5, 4}, s1.ToSlice())
+func (s *Set[T]) Merge(other Set[T]) {
+	for e := range other.All() {
+		s.Insert(e)
+	}
+}
+
+// Union creates a new set with the elements of the current set in order,
+// followed by the new elements of the other set in its order.
+//
+// Example:
+//
+//	s1 := orderedset.New(3, 1, 2)
+//	s2 := orderedset.New(5, 2, 4)
+//	require.Equal([]int{3, 1, 2, 5, 4}, s1.Union(s2).ToSlice())
+//	require.Equal([]int{3, 1, 2}, s1.ToSlice()) // s1 should be unchanged
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	result := s.Copy()
+	result.Merge(other)
+	return result
+}
+
+// Intersection creates a new set with the elements of the current set that are also in the other set,
+// in the current set's order.
+//
+// Example:
+//
+//	s1 := orderedset.New(4, 3, 2, 1)
+//	s2 := orderedset.New(1, 3, 5)
+//	require.Equal([]int{3, 1}, s1.Intersection(s2).ToSlice())
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	result := New[T]()
+	for e := range s.All() {
+		if other.Contains(e) {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+// Difference creates a new set with the elements of the current set that are not in the other set,
+// in the current set's order.
+//
+// Example:
+//
+//	s1 := orderedset.New(4, 3, 2, 1)
+//	s2 := orderedset.New(1, 3, 5)
+//	require.Equal([]int{4, 2}, s1.Difference(s2).ToSlice())
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	result := New[T]()
+	for e := range s.All() {
+		if !other.Contains(e) {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference creates a new set with the elements in either set but not in both.
+// Elements of the current set come first, followed by those of the other set.
+//
+// Example:
+//
+//	s1 := orderedset.New(4, 3, 2, 1)
+//	s2 := orderedset.New(6, 1, 3, 5)
+//	require.Equal([]int{4, 2, 6, 5}, s1.SymmetricDifference(s2).ToSlice())
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	result := s.Difference(other)
+	for e := range other.All() {
+		if !s.Contains(e) {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,199 @@
+package orderedset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/orderedset"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	s1 := orderedset.New[int]()
+	s2 := orderedset.New(3, 1, 2, 1)
+	require.True(s1.IsEmpty())
+	require.Equal([]int{3, 1, 2}, s2.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestInsert() {
+	require := require.New(s.T())
+	// testdoc begin Set.Insert
+	st := orderedset.New[string]()
+	st.Insert("b")
+	st.Insert("a", "c", "b")
+	require.Equal([]string{"b", "a", "c"}, st.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin Set.Remove
+	st := orderedset.New(1, 2, 3, 4)
+	st.Remove(2)
+	st.Remove(4, 5)
+	require.Equal([]int{1, 3}, st.ToSlice())
+	// testdoc end
+
+	// re-inserting a removed element appends it
+	st.Insert(2)
+	require.Equal([]int{1, 3, 2}, st.ToSlice())
+	st.Remove(1, 3, 2)
+	require.True(st.IsEmpty())
+	require.Empty(st.ToSlice())
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Set.Contains
+	st := orderedset.New(1, 2, 3)
+	require.True(st.Contains(1))
+	require.False(st.Contains(4))
+	// testdoc end
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin Set.Len
+	st := orderedset.New[int]()
+	require.Equal(0, st.Len())
+	st.Insert(1, 1, 2)
+	require.Equal(2, st.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin Set.Equals
+	s1 := orderedset.New(1, 2, 3)
+	require.True(s1.Equals(orderedset.New(3, 2, 1)))
+	require.False(s1.Equals(orderedset.New(1, 2)))
+	// testdoc end
+	require.False(s1.Equals(orderedset.New(1, 2, 4)))
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsEmpty
+	st := orderedset.New[int]()
+	require.True(st.IsEmpty())
+	st.Insert(1)
+	require.False(st.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin Set.All
+	st := orderedset.New("c", "a", "b")
+	var got []string
+	for e := range st.All() {
+		got = append(got, e)
+	}
+	require.Equal([]string{"c", "a", "b"}, got)
+	// testdoc end
+
+	// removing the visited element while iterating
+	for e := range st.All() {
+		st.Remove(e)
+	}
+	require.True(st.IsEmpty())
+
+	var zero orderedset.Set[int]
+	require.Empty(slices.Collect(zero.All()))
+
+	st = orderedset.New("a", "b")
+	got = nil
+	for e := range st.All() {
+		got = append(got, e)
+		break
+	}
+	require.Equal([]string{"a"}, got)
+}
+
+func (s *Suite) TestToSlice() {
+	require := require.New(s.T())
+	// testdoc begin Set.ToSlice
+	st := orderedset.New(5, 3, 9)
+	require.Equal([]int{5, 3, 9}, st.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin Set.Clear
+	st := orderedset.New(1, 2)
+	st.Clear()
+	require.True(st.IsEmpty())
+	// testdoc end
+	st.Insert(3)
+	require.Equal([]int{3}, st.ToSlice())
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin Set.Copy
+	s1 := orderedset.New(3, 1, 2)
+	s2 := s1.Copy()
+	s1.Insert(4)
+	require.Equal([]int{3, 1, 2}, s2.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestMerge() {
+	require := require.New(s.T())
+	// testdoc begin Set.Merge
+	s1 := orderedset.New(3, 1)
+	s1.Merge(orderedset.New(5, 1, 4))
+	require.Equal([]int{3, 1, 5, 4}, s1.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Set.Union
+	s1 := orderedset.New(3, 1, 2)
+	s2 := orderedset.New(5, 2, 4)
+	require.Equal([]int{3, 1, 2, 5, 4}, s1.Union(s2).ToSlice())
+	require.Equal([]int{3, 1, 2}, s1.ToSlice()) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin Set.Intersection
+	s1 := orderedset.New(4, 3, 2, 1)
+	s2 := orderedset.New(1, 3, 5)
+	require.Equal([]int{3, 1}, s1.Intersection(s2).ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin Set.Difference
+	s1 := orderedset.New(4, 3, 2, 1)
+	s2 := orderedset.New(1, 3, 5)
+	require.Equal([]int{4, 2}, s1.Difference(s2).ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestSymmetricDifference() {
+	require := require.New(s.T())
+	// testdoc begin Set.SymmetricDifference
+	s1 := orderedset.New(4, 3, 2, 1)
+	s2 := orderedset.New(6, 1, 3, 5)
+	require.Equal([]int{4, 2, 6, 5}, s1.SymmetricDifference(s2).ToSlice())
+	// testdoc end
+}
@@ -0,0 +1,319 @@
+package probabilistic
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Bloom[string])(nil)
+	_ encoding.BinaryUnmarshaler = (*Bloom[string])(nil)
+)
+
+const bloomMagic = "BF"
+
+// Bloom is a Bloom filter: a bit array in which each element sets k bits.
+// It never reports a false negative, and its false positive rate grows as elements are added.
+//
+// A Bloom must be created with NewBloom or NewBloomWithSize.
+type Bloom[T any] struct {
+	words []uint64
+	m     uint64
+	k     uint32
+	hash  HashFunc[T]
+}
+
+// NewBloom creates a Bloom filter sized to hold n elements with a false positive rate of about p.
+// It panics if p is not in the open interval (0, 1).
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(1000, 0.01, probabilistic.String())
+//	require.Equal(uint64(9586), f.BitLen())
+//	require.Equal(uint32(7), f.HashCount())
+func NewBloom[T any](n int, p float64, hash HashFunc[T]) *Bloom[T] {
+	m, k := bloomSize(n, p)
+	return NewBloomWithSize(m, k, hash)
+}
+
+// NewBloomWithSize creates a Bloom filter with m bits and k hash functions.
+// It panics if m or k is zero or k exceeds MaxHashCount.
+//
+// Example:
+//
+//	f := probabilistic.NewBloomWithSize(1024, 3, probabilistic.Integer[int]())
+//	require.Equal(uint64(1024), f.BitLen())
+//	require.Equal(uint32(3), f.HashCount())
+func NewBloomWithSize[T any](m uint64, k uint32, hash HashFunc[T]) *Bloom[T] {
+	if m == 0 || k == 0 {
+		panic("probabilistic: bloom filter size and hash count must be positive")
+	}
+	if k > MaxHashCount {
+		panic("probabilistic: bloom filter hash count exceeds MaxHashCount")
+	}
+	return &Bloom[T]{
+		words: make([]uint64, (m+63)/64),
+		m:     m,
+		k:     k,
+		hash:  hash,
+	}
+}
+
+// UnmarshalBloom decodes a Bloom filter encoded by MarshalBinary.
+// hash must be the HashFunc the filter was built with.
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	data, err := f.MarshalBinary()
+//	require.NoError(err)
+//
+//	decoded, err := probabilistic.UnmarshalBloom(data, probabilistic.String())
+//	require.NoError(err)
+//	require.True(decoded.Contains("a"))
+func UnmarshalBloom[T any](data []byte, hash HashFunc[T]) (*Bloom[T], error) {
+	b := &Bloom[T]{hash: hash}
+	if err := b.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Add inserts one or more elements into the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	f.Add("b", "c")
+//	require.True(f.Contains("b"))
+func (b *Bloom[T]) Add(elements ...T) {
+	for _, e := range elements {
+		locations(b.hash(e), b.k, b.m, func(i uint64) bool {
+			b.words[i/64] |= 1 << (i % 64)
+			return true
+		})
+	}
+}
+
+// Contains reports whether the element may be in the filter.
+// A false result is definite; a true result is wrong with the filter's false positive rate.
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	require.True(f.Contains("a"))
+//	require.False(f.Contains("z"))
+func (b *Bloom[T]) Contains(element T) bool {
+	return locations(b.hash(element), b.k, b.m, func(i uint64) bool {
+		return b.words[i/64]&(1<<(i%64)) != 0
+	})
+}
+
+// BitLen returns the number of bits in the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewBloomWithSize(100, 2, probabilistic.String())
+//	require.Equal(uint64(100), f.BitLen())
+func (b *Bloom[T]) BitLen() uint64 {
+	return b.m
+}
+
+// HashCount returns the number of bits set for each element.
+//
+// Example:
+//
+//	f := probabilistic.NewBloomWithSize(100, 2, probabilistic.String())
+//	require.Equal(uint32(2), f.HashCount())
+func (b *Bloom[T]) HashCount() uint32 {
+	return b.k
+}
+
+// EstimatedLen estimates the number of distinct elements added from the number of set bits.
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(10000, 0.01, probabilistic.Integer[int]())
+//	for i := range 1000 {
+//		f.Add(i)
+//	}
+//	require.InDelta(1000, f.EstimatedLen(), 50)
+func (b *Bloom[T]) EstimatedLen() int {
+	x := b.setBits()
+	if x == b.m {
+		return math.MaxInt
+	}
+	m, k := float64(b.m), float64(b.k)
+	return int(math.Round(-m / k * math.Log1p(-float64(x)/m)))
+}
+
+// FalsePositiveRate estimates the current false positive rate from the number of set bits.
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(1000, 0.01, probabilistic.Integer[int]())
+//	require.Zero(f.FalsePositiveRate())
+//	for i := range 1000 {
+//		f.Add(i)
+//	}
+//	require.InDelta(0.01, f.FalsePositiveRate(), 0.005)
+func (b *Bloom[T]) FalsePositiveRate() float64 {
+	return math.Pow(float64(b.setBits())/float64(b.m), float64(b.k))
+}
+
+// IsEmpty checks if no element has been added.
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	require.True(f.IsEmpty())
+//	f.Add("a")
+//	require.False(f.IsEmpty())
+func (b *Bloom[T]) IsEmpty() bool {
+	return b.setBits() == 0
+}
+
+// Clear removes all elements from the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	f.Clear()
+//	require.False(f.Contains("a"))
+func (b *Bloom[T]) Clear() {
+	clear(b.words)
+}
+
+// Copy creates a copy of the filter.
+//
+// Example:
+//
+//	f1 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f2 := f1.Copy()
+//	f1.Add("a")
+//	require.False(f2.Contains("a"))
+func (b *Bloom[T]) Copy() *Bloom[T] {
+	c := *b
+	c.words = append([]uint64(nil), b.words...)
+	return &c
+}
+
+// Union creates a new filter containing the elements of both filters.
+// Returns ErrIncompatible if the filters differ in size or hash count.
+// Both filters must use the same HashFunc.
+//
+// Example:
+//
+//	f1 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f2 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f1.Add("a")
+//	f2.Add("b")
+//	u, err := f1.Union(f2)
+//	require.NoError(err)
+//	require.True(u.Contains("a"))
+//	require.True(u.Contains("b"))
+//
+//	_, err = f1.Union(probabilistic.NewBloom(200, 0.01, probabilistic.String()))
+//	require.ErrorIs(err, probabilistic.ErrIncompatible)
+func (b *Bloom[T]) Union(other *Bloom[T]) (*Bloom[T], error) {
+	if b.m != other.m || b.k != other.k {
+		return nil, fmt.Errorf("%w: bloom filters with %d/%d and %d/%d bits/hashes",
+			ErrIncompatible, b.m, b.k, other.m, other.k)
+	}
+	result := b.Copy()
+	for i, w := range other.words {
+		result.words[i] |= w
+	}
+	return result, nil
+}
+
+// MarshalBinary encodes the filter parameters and bits.
+// The hash function is not encoded.
+//
+// Example:
+//
+//	f := probabilistic.NewBloomWithSize(128, 2, probabilistic.String())
+//	data, err := f.MarshalBinary()
+//	require.NoError(err)
+//	require.Len(data, 3+4+8+16)
+func (b *Bloom[T]) MarshalBinary() ([]byte, error) {
+	data := appendHeader(make([]byte, 0, 15+8*len(b.words)), bloomMagic)
+	data = binary.LittleEndian.AppendUint32(data, b.k)
+	data = binary.LittleEndian.AppendUint64(data, b.m)
+	for _, w := range b.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary, replacing its contents.
+// The filter keeps its HashFunc, which must be the one the encoded filter was built with.
+//
+// Example:
+//
+//	f1 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+//	f1.Add("a")
+//	data, err := f1.MarshalBinary()
+//	require.NoError(err)
+//
+//	f2 := probabilistic.NewBloom(1, 0.5, probabilistic.String())
+//	err = f2.UnmarshalBinary(data)
+//	require.NoError(err)
+//	require.True(f2.Contains("a"))
+func (b *Bloom[T]) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, bloomMagic)
+	if err != nil {
+		return err
+	}
+	if len(data) < 12 {
+		return fmt.Errorf("%w: truncated bloom filter", ErrInvalidFormat)
+	}
+	k := binary.LittleEndian.Uint32(data)
+	m := binary.LittleEndian.Uint64(data[4:])
+	data = data[12:]
+	// m is bounded by the payload first, so that rounding it up to words cannot overflow
+	if m == 0 || k == 0 || m > uint64(len(data))*8 || uint64(len(data)) != (m+63)/64*8 {
+		return fmt.Errorf("%w: bloom filter with %d bits and %d bytes", ErrInvalidFormat, m, len(data))
+	}
+	if k > MaxHashCount {
+		return fmt.Errorf("%w: bloom filter with %d hash functions", ErrInvalidFormat, k)
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	if tail := m % 64; tail != 0 && words[len(words)-1]>>tail != 0 {
+		return fmt.Errorf("%w: bits set beyond filter size", ErrInvalidFormat)
+	}
+	b.words, b.m, b.k = words, m, k
+	return nil
+}
+
+func (b *Bloom[T]) setBits() uint64 {
+	var n int
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return uint64(n)
+}
+
+// bloomSize returns the optimal number of bits and hash functions for n elements
+// at false positive rate p.
+func bloomSize(n int, p float64) (uint64, uint32) {
+	if !(p > 0 && p < 1) {
+		panic("probabilistic: false positive rate must be in (0, 1)")
+	}
+	n = max(n, 1)
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return uint64(m), uint32(min(max(k, 1), MaxHashCount))
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,260 @@
+package probabilistic_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/probabilistic"
+)
+
+type BloomSuite struct {
+	suite.Suite
+}
+
+func TestBloomSuite(t *testing.T) {
+	suite.Run(t, new(BloomSuite))
+}
+
+func (s *BloomSuite) TestNewBloom() {
+	require := require.New(s.T())
+	// testdoc begin NewBloom
+	f := probabilistic.NewBloom(1000, 0.01, probabilistic.String())
+	require.Equal(uint64(9586), f.BitLen())
+	require.Equal(uint32(7), f.HashCount())
+	// testdoc end
+
+	require.Panics(func() { probabilistic.NewBloom(10, 0, probabilistic.String()) })
+	require.Panics(func() { probabilistic.NewBloom(10, 1, probabilistic.String()) })
+	require.NotPanics(func() { probabilistic.NewBloom(0, 0.5, probabilistic.String()) })
+	require.Equal(uint32(probabilistic.MaxHashCount), probabilistic.NewBloom(1, 1e-300, probabilistic.String()).HashCount())
+}
+
+func (s *BloomSuite) TestNewBloomWithSize() {
+	require := require.New(s.T())
+	// testdoc begin NewBloomWithSize
+	f := probabilistic.NewBloomWithSize(1024, 3, probabilistic.Integer[int]())
+	require.Equal(uint64(1024), f.BitLen())
+	require.Equal(uint32(3), f.HashCount())
+	// testdoc end
+	require.Panics(func() { probabilistic.NewBloomWithSize(0, 3, probabilistic.Integer[int]()) })
+	require.Panics(func() { probabilistic.NewBloomWithSize(10, 0, probabilistic.Integer[int]()) })
+	require.Panics(func() { probabilistic.NewBloomWithSize(10, probabilistic.MaxHashCount+1, probabilistic.Integer[int]()) })
+}
+
+func (s *BloomSuite) TestUnmarshalBloom() {
+	require := require.New(s.T())
+	// testdoc begin UnmarshalBloom
+	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	data, err := f.MarshalBinary()
+	require.NoError(err)
+
+	decoded, err := probabilistic.UnmarshalBloom(data, probabilistic.String())
+	require.NoError(err)
+	require.True(decoded.Contains("a"))
+	// testdoc end
+
+	require.Equal(f.BitLen(), decoded.BitLen())
+	require.Equal(f.HashCount(), decoded.HashCount())
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("XX\x01"),
+		data[:2],
+		append([]byte{'B', 'F', 2}, data[3:]...), // unknown version
+		data[:10],
+		data[:len(data)-1],
+		append(append([]byte(nil), data...), 0),
+	} {
+		_, err := probabilistic.UnmarshalBloom(bad, probabilistic.String())
+		require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+	}
+
+	// a bit count that overflows when rounded up to words is rejected without panicking
+	for _, m := range []uint64{^uint64(0), ^uint64(0) - 62, 1 << 63, 65} {
+		header := binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32([]byte("BF\x01"), 3), m)
+		for _, payload := range [][]byte{nil, make([]byte, 8)} {
+			_, err := probabilistic.UnmarshalBloom(append(header, payload...), probabilistic.String())
+			require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+		}
+	}
+
+	// a hash count beyond MaxHashCount is rejected, so decoding cannot make lookups arbitrarily slow
+	for _, k := range []uint32{probabilistic.MaxHashCount + 1, ^uint32(0)} {
+		header := binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32([]byte("BF\x01"), k), 64)
+		_, err := probabilistic.UnmarshalBloom(append(header, make([]byte, 8)...), probabilistic.String())
+		require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+	}
+
+	// a bit beyond the filter size is rejected
+	small := probabilistic.NewBloomWithSize(10, 1, probabilistic.String())
+	data, err = small.MarshalBinary()
+	require.NoError(err)
+	data[len(data)-1] = 0x80
+	_, err = probabilistic.UnmarshalBloom(data, probabilistic.String())
+	require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+}
+
+func (s *BloomSuite) TestAdd() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.Add
+	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	f.Add("b", "c")
+	require.True(f.Contains("b"))
+	// testdoc end
+}
+
+func (s *BloomSuite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.Contains
+	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	require.True(f.Contains("a"))
+	require.False(f.Contains("z"))
+	// testdoc end
+}
+
+func (s *BloomSuite) TestFalsePositives() {
+	require := require.New(s.T())
+	const n = 10000
+	f := probabilistic.NewBloom(n, 0.01, probabilistic.String())
+	for i := range n {
+		f.Add(fmt.Sprint("in-", i))
+	}
+	for i := range n {
+		require.True(f.Contains(fmt.Sprint("in-", i))) // no false negatives
+	}
+	fp := 0
+	for i := range n {
+		if f.Contains(fmt.Sprint("out-", i)) {
+			fp++
+		}
+	}
+	require.Less(float64(fp)/n, 0.02)
+}
+
+func (s *BloomSuite) TestBitLen() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.BitLen
+	f := probabilistic.NewBloomWithSize(100, 2, probabilistic.String())
+	require.Equal(uint64(100), f.BitLen())
+	// testdoc end
+}
+
+func (s *BloomSuite) TestHashCount() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.HashCount
+	f := probabilistic.NewBloomWithSize(100, 2, probabilistic.String())
+	require.Equal(uint32(2), f.HashCount())
+	// testdoc end
+}
+
+func (s *BloomSuite) TestEstimatedLen() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.EstimatedLen
+	f := probabilistic.NewBloom(10000, 0.01, probabilistic.Integer[int]())
+	for i := range 1000 {
+		f.Add(i)
+	}
+	require.InDelta(1000, f.EstimatedLen(), 50)
+	// testdoc end
+
+	full := probabilistic.NewBloomWithSize(1, 1, probabilistic.Integer[int]())
+	full.Add(1)
+	require.Greater(full.EstimatedLen(), 1<<40)
+}
+
+func (s *BloomSuite) TestFalsePositiveRate() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.FalsePositiveRate
+	f := probabilistic.NewBloom(1000, 0.01, probabilistic.Integer[int]())
+	require.Zero(f.FalsePositiveRate())
+	for i := range 1000 {
+		f.Add(i)
+	}
+	require.InDelta(0.01, f.FalsePositiveRate(), 0.005)
+	// testdoc end
+}
+
+func (s *BloomSuite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.IsEmpty
+	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	require.True(f.IsEmpty())
+	f.Add("a")
+	require.False(f.IsEmpty())
+	// testdoc end
+}
+
+func (s *BloomSuite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.Clear
+	f := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	f.Clear()
+	require.False(f.Contains("a"))
+	// testdoc end
+	require.True(f.IsEmpty())
+}
+
+func (s *BloomSuite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.Copy
+	f1 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f2 := f1.Copy()
+	f1.Add("a")
+	require.False(f2.Contains("a"))
+	// testdoc end
+}
+
+func (s *BloomSuite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.Union
+	f1 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f2 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f1.Add("a")
+	f2.Add("b")
+	u, err := f1.Union(f2)
+	require.NoError(err)
+	require.True(u.Contains("a"))
+	require.True(u.Contains("b"))
+
+	_, err = f1.Union(probabilistic.NewBloom(200, 0.01, probabilistic.String()))
+	require.ErrorIs(err, probabilistic.ErrIncompatible)
+	// testdoc end
+	require.False(f1.Contains("b")) // f1 should be unchanged
+}
+
+func (s *BloomSuite) TestMarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.MarshalBinary
+	f := probabilistic.NewBloomWithSize(128, 2, probabilistic.String())
+	data, err := f.MarshalBinary()
+	require.NoError(err)
+	require.Len(data, 3+4+8+16)
+	// testdoc end
+}
+
+func (s *BloomSuite) TestUnmarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin Bloom.UnmarshalBinary
+	f1 := probabilistic.NewBloom(100, 0.01, probabilistic.String())
+	f1.Add("a")
+	data, err := f1.MarshalBinary()
+	require.NoError(err)
+
+	f2 := probabilistic.NewBloom(1, 0.5, probabilistic.String())
+	err = f2.UnmarshalBinary(data)
+	require.NoError(err)
+	require.True(f2.Contains("a"))
+	// testdoc end
+
+	// a failed decode leaves the filter unchanged
+	require.Error(f2.UnmarshalBinary(data[:5]))
+	require.True(f2.Contains("a"))
+}
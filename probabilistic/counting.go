@@ -0,0 +1,326 @@
+package probabilistic
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*CountingBloom[string])(nil)
+	_ encoding.BinaryUnmarshaler = (*CountingBloom[string])(nil)
+)
+
+const (
+	countingMagic = "CB"
+	// maxCount is the largest value of a 4-bit counter. Counters that reach it stick,
+	// so that removals can never cause a false negative.
+	maxCount = 15
+)
+
+// CountingBloom is a Bloom filter with a 4-bit counter per position instead of a single bit,
+// which makes it possible to remove elements.
+//
+// A CountingBloom must be created with NewCountingBloom or NewCountingBloomWithSize.
+type CountingBloom[T any] struct {
+	counters []uint8
+	m        uint64
+	k        uint32
+	hash     HashFunc[T]
+}
+
+// NewCountingBloom creates a counting Bloom filter sized to hold n elements
+// with a false positive rate of about p.
+// It panics if p is not in the open interval (0, 1).
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(1000, 0.01, probabilistic.String())
+//	require.Equal(uint64(9586), f.CounterLen())
+//	require.Equal(uint32(7), f.HashCount())
+func NewCountingBloom[T any](n int, p float64, hash HashFunc[T]) *CountingBloom[T] {
+	m, k := bloomSize(n, p)
+	return NewCountingBloomWithSize(m, k, hash)
+}
+
+// NewCountingBloomWithSize creates a counting Bloom filter with m counters and k hash functions.
+// It panics if m or k is zero or k exceeds MaxHashCount.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloomWithSize(1024, 3, probabilistic.Integer[int]())
+//	require.Equal(uint64(1024), f.CounterLen())
+func NewCountingBloomWithSize[T any](m uint64, k uint32, hash HashFunc[T]) *CountingBloom[T] {
+	if m == 0 || k == 0 {
+		panic("probabilistic: bloom filter size and hash count must be positive")
+	}
+	if k > MaxHashCount {
+		panic("probabilistic: bloom filter hash count exceeds MaxHashCount")
+	}
+	return &CountingBloom[T]{
+		counters: make([]uint8, (m+1)/2),
+		m:        m,
+		k:        k,
+		hash:     hash,
+	}
+}
+
+// UnmarshalCountingBloom decodes a counting Bloom filter encoded by MarshalBinary.
+// hash must be the HashFunc the filter was built with.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	data, err := f.MarshalBinary()
+//	require.NoError(err)
+//
+//	decoded, err := probabilistic.UnmarshalCountingBloom(data, probabilistic.String())
+//	require.NoError(err)
+//	require.True(decoded.Contains("a"))
+func UnmarshalCountingBloom[T any](data []byte, hash HashFunc[T]) (*CountingBloom[T], error) {
+	b := &CountingBloom[T]{hash: hash}
+	if err := b.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Add inserts one or more elements into the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	f.Add("a", "b")
+//	require.Equal(2, f.Count("a"))
+func (b *CountingBloom[T]) Add(elements ...T) {
+	for _, e := range elements {
+		locations(b.hash(e), b.k, b.m, func(i uint64) bool {
+			if c := b.get(i); c < maxCount {
+				b.set(i, c+1)
+			}
+			return true
+		})
+	}
+}
+
+// Remove deletes one occurrence of the element from the filter.
+// It returns false, leaving the filter unchanged, if the element is definitely not present.
+// Removing an element that was never added may remove another element that shares its positions.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f.Add("a", "b")
+//	require.True(f.Remove("a"))
+//	require.False(f.Contains("a"))
+//	require.True(f.Contains("b"))
+//	require.False(f.Remove("a"))
+func (b *CountingBloom[T]) Remove(element T) bool {
+	h := b.hash(element)
+	if !b.contains(h) {
+		return false
+	}
+	locations(h, b.k, b.m, func(i uint64) bool {
+		if c := b.get(i); c > 0 && c < maxCount {
+			b.set(i, c-1)
+		}
+		return true
+	})
+	return true
+}
+
+// Contains reports whether the element may be in the filter.
+// A false result is definite; a true result is wrong with the filter's false positive rate.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	require.True(f.Contains("a"))
+//	require.False(f.Contains("z"))
+func (b *CountingBloom[T]) Contains(element T) bool {
+	return b.contains(b.hash(element))
+}
+
+// Count returns an upper bound on the number of times the element was added, up to 15.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f.Add("a", "a", "a")
+//	require.Equal(3, f.Count("a"))
+//	require.Equal(0, f.Count("z"))
+func (b *CountingBloom[T]) Count(element T) int {
+	count := uint8(maxCount)
+	locations(b.hash(element), b.k, b.m, func(i uint64) bool {
+		count = min(count, b.get(i))
+		return count > 0
+	})
+	return int(count)
+}
+
+// CounterLen returns the number of counters in the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloomWithSize(100, 2, probabilistic.String())
+//	require.Equal(uint64(100), f.CounterLen())
+func (b *CountingBloom[T]) CounterLen() uint64 {
+	return b.m
+}
+
+// HashCount returns the number of counters incremented for each element.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloomWithSize(100, 2, probabilistic.String())
+//	require.Equal(uint32(2), f.HashCount())
+func (b *CountingBloom[T]) HashCount() uint32 {
+	return b.k
+}
+
+// IsEmpty checks if every counter is zero.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	require.True(f.IsEmpty())
+//	f.Add("a")
+//	require.False(f.IsEmpty())
+//	f.Remove("a")
+//	require.True(f.IsEmpty())
+func (b *CountingBloom[T]) IsEmpty() bool {
+	for _, c := range b.counters {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear removes all elements from the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f.Add("a")
+//	f.Clear()
+//	require.False(f.Contains("a"))
+func (b *CountingBloom[T]) Clear() {
+	clear(b.counters)
+}
+
+// Copy creates a copy of the filter.
+//
+// Example:
+//
+//	f1 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f2 := f1.Copy()
+//	f1.Add("a")
+//	require.False(f2.Contains("a"))
+func (b *CountingBloom[T]) Copy() *CountingBloom[T] {
+	c := *b
+	c.counters = append([]uint8(nil), b.counters...)
+	return &c
+}
+
+// Union creates a new filter whose counters are the sums of the counters of both filters.
+// Returns ErrIncompatible if the filters differ in size or hash count.
+// Both filters must use the same HashFunc.
+//
+// Example:
+//
+//	f1 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f2 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f1.Add("a")
+//	f2.Add("a", "b")
+//	u, err := f1.Union(f2)
+//	require.NoError(err)
+//	require.Equal(2, u.Count("a"))
+//	require.True(u.Contains("b"))
+func (b *CountingBloom[T]) Union(other *CountingBloom[T]) (*CountingBloom[T], error) {
+	if b.m != other.m || b.k != other.k {
+		return nil, fmt.Errorf("%w: counting bloom filters with %d/%d and %d/%d counters/hashes",
+			ErrIncompatible, b.m, b.k, other.m, other.k)
+	}
+	result := b.Copy()
+	for i := range b.m {
+		result.set(i, min(b.get(i)+other.get(i), maxCount))
+	}
+	return result, nil
+}
+
+// MarshalBinary encodes the filter parameters and counters.
+// The hash function is not encoded.
+//
+// Example:
+//
+//	f := probabilistic.NewCountingBloomWithSize(128, 2, probabilistic.String())
+//	data, err := f.MarshalBinary()
+//	require.NoError(err)
+//	require.Len(data, 3+4+8+64)
+func (b *CountingBloom[T]) MarshalBinary() ([]byte, error) {
+	data := appendHeader(make([]byte, 0, 15+len(b.counters)), countingMagic)
+	data = binary.LittleEndian.AppendUint32(data, b.k)
+	data = binary.LittleEndian.AppendUint64(data, b.m)
+	return append(data, b.counters...), nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary, replacing its contents.
+// The filter keeps its HashFunc, which must be the one the encoded filter was built with.
+//
+// Example:
+//
+//	f1 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+//	f1.Add("a")
+//	data, err := f1.MarshalBinary()
+//	require.NoError(err)
+//
+//	f2 := probabilistic.NewCountingBloom(1, 0.5, probabilistic.String())
+//	err = f2.UnmarshalBinary(data)
+//	require.NoError(err)
+//	require.True(f2.Remove("a"))
+func (b *CountingBloom[T]) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, countingMagic)
+	if err != nil {
+		return err
+	}
+	if len(data) < 12 {
+		return fmt.Errorf("%w: truncated counting bloom filter", ErrInvalidFormat)
+	}
+	k := binary.LittleEndian.Uint32(data)
+	m := binary.LittleEndian.Uint64(data[4:])
+	data = data[12:]
+	// m is bounded by the payload first, so that rounding it up to bytes cannot overflow
+	if m == 0 || k == 0 || m > uint64(len(data))*2 || uint64(len(data)) != (m+1)/2 {
+		return fmt.Errorf("%w: counting bloom filter with %d counters and %d bytes", ErrInvalidFormat, m, len(data))
+	}
+	if k > MaxHashCount {
+		return fmt.Errorf("%w: counting bloom filter with %d hash functions", ErrInvalidFormat, k)
+	}
+	if m%2 == 1 && data[len(data)-1]>>4 != 0 {
+		return fmt.Errorf("%w: counter set beyond filter size", ErrInvalidFormat)
+	}
+	b.counters, b.m, b.k = append([]uint8(nil), data...), m, k
+	return nil
+}
+
+func (b *CountingBloom[T]) contains(h uint64) bool {
+	return locations(h, b.k, b.m, func(i uint64) bool {
+		return b.get(i) > 0
+	})
+}
+
+// get returns the i-th counter. Two counters are packed in each byte, low nibble first.
+func (b *CountingBloom[T]) get(i uint64) uint8 {
+	return b.counters[i/2] >> (4 * (i % 2)) & 0xF
+}
+
+func (b *CountingBloom[T]) set(i uint64, c uint8) {
+	shift := 4 * (i % 2)
+	b.counters[i/2] = b.counters[i/2]&^(0xF<<shift) | c<<shift
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
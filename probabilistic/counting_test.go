@@ -0,0 +1,268 @@
+package probabilistic_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/probabilistic"
+)
+
+type CountingBloomSuite struct {
+	suite.Suite
+}
+
+func TestCountingBloomSuite(t *testing.T) {
+	suite.Run(t, new(CountingBloomSuite))
+}
+
+func (s *CountingBloomSuite) TestNewCountingBloom() {
+	require := require.New(s.T())
+	// testdoc begin NewCountingBloom
+	f := probabilistic.NewCountingBloom(1000, 0.01, probabilistic.String())
+	require.Equal(uint64(9586), f.CounterLen())
+	require.Equal(uint32(7), f.HashCount())
+	// testdoc end
+	require.Panics(func() { probabilistic.NewCountingBloom(10, 1.5, probabilistic.String()) })
+}
+
+func (s *CountingBloomSuite) TestNewCountingBloomWithSize() {
+	require := require.New(s.T())
+	// testdoc begin NewCountingBloomWithSize
+	f := probabilistic.NewCountingBloomWithSize(1024, 3, probabilistic.Integer[int]())
+	require.Equal(uint64(1024), f.CounterLen())
+	// testdoc end
+	require.Panics(func() { probabilistic.NewCountingBloomWithSize(0, 3, probabilistic.Integer[int]()) })
+	require.Panics(func() {
+		probabilistic.NewCountingBloomWithSize(10, probabilistic.MaxHashCount+1, probabilistic.Integer[int]())
+	})
+}
+
+func (s *CountingBloomSuite) TestUnmarshalCountingBloom() {
+	require := require.New(s.T())
+	// testdoc begin UnmarshalCountingBloom
+	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	data, err := f.MarshalBinary()
+	require.NoError(err)
+
+	decoded, err := probabilistic.UnmarshalCountingBloom(data, probabilistic.String())
+	require.NoError(err)
+	require.True(decoded.Contains("a"))
+	// testdoc end
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("BF\x01"),
+		data[:10],
+		data[:len(data)-1],
+	} {
+		_, err := probabilistic.UnmarshalCountingBloom(bad, probabilistic.String())
+		require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+	}
+
+	// a counter count that overflows when rounded up to bytes is rejected without panicking
+	for _, m := range []uint64{^uint64(0), ^uint64(0) - 1, 1 << 63, 3} {
+		header := binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32([]byte("CB\x01"), 3), m)
+		for _, payload := range [][]byte{nil, {0}} {
+			_, err := probabilistic.UnmarshalCountingBloom(append(header, payload...), probabilistic.String())
+			require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+		}
+	}
+
+	// a hash count beyond MaxHashCount is rejected, so decoding cannot make lookups arbitrarily slow
+	for _, k := range []uint32{probabilistic.MaxHashCount + 1, ^uint32(0)} {
+		header := binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32([]byte("CB\x01"), k), 2)
+		_, err := probabilistic.UnmarshalCountingBloom(append(header, 0), probabilistic.String())
+		require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+	}
+
+	// a counter beyond an odd filter size is rejected
+	odd := probabilistic.NewCountingBloomWithSize(3, 1, probabilistic.String())
+	data, err = odd.MarshalBinary()
+	require.NoError(err)
+	data[len(data)-1] = 0x10
+	_, err = probabilistic.UnmarshalCountingBloom(data, probabilistic.String())
+	require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+}
+
+func (s *CountingBloomSuite) TestAdd() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.Add
+	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	f.Add("a", "b")
+	require.Equal(2, f.Count("a"))
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.Remove
+	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f.Add("a", "b")
+	require.True(f.Remove("a"))
+	require.False(f.Contains("a"))
+	require.True(f.Contains("b"))
+	require.False(f.Remove("a"))
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestRemoveMany() {
+	require := require.New(s.T())
+	const n = 2000
+	f := probabilistic.NewCountingBloom(n, 0.01, probabilistic.Integer[int]())
+	for i := range n {
+		f.Add(i)
+	}
+	for i := 0; i < n; i += 2 {
+		require.True(f.Remove(i))
+	}
+	for i := 1; i < n; i += 2 {
+		require.True(f.Contains(i)) // removals never cause false negatives
+	}
+	for i := 1; i < n; i += 2 {
+		require.True(f.Remove(i))
+	}
+	require.True(f.IsEmpty())
+}
+
+func (s *CountingBloomSuite) TestSaturation() {
+	require := require.New(s.T())
+	f := probabilistic.NewCountingBloomWithSize(64, 2, probabilistic.String())
+	for range 20 {
+		f.Add("a")
+	}
+	require.Equal(15, f.Count("a"))
+	for range 20 {
+		f.Remove("a")
+	}
+	// saturated counters stick so the element can never become a false negative
+	require.True(f.Contains("a"))
+}
+
+func (s *CountingBloomSuite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.Contains
+	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	require.True(f.Contains("a"))
+	require.False(f.Contains("z"))
+	// testdoc end
+
+	g := probabilistic.NewCountingBloom(5000, 0.01, probabilistic.String())
+	for i := range 5000 {
+		g.Add(fmt.Sprint("in-", i))
+	}
+	fp := 0
+	for i := range 5000 {
+		if g.Contains(fmt.Sprint("out-", i)) {
+			fp++
+		}
+	}
+	require.Less(float64(fp)/5000, 0.02)
+}
+
+func (s *CountingBloomSuite) TestCount() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.Count
+	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f.Add("a", "a", "a")
+	require.Equal(3, f.Count("a"))
+	require.Equal(0, f.Count("z"))
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestCounterLen() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.CounterLen
+	f := probabilistic.NewCountingBloomWithSize(100, 2, probabilistic.String())
+	require.Equal(uint64(100), f.CounterLen())
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestHashCount() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.HashCount
+	f := probabilistic.NewCountingBloomWithSize(100, 2, probabilistic.String())
+	require.Equal(uint32(2), f.HashCount())
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.IsEmpty
+	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	require.True(f.IsEmpty())
+	f.Add("a")
+	require.False(f.IsEmpty())
+	f.Remove("a")
+	require.True(f.IsEmpty())
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.Clear
+	f := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f.Add("a")
+	f.Clear()
+	require.False(f.Contains("a"))
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.Copy
+	f1 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f2 := f1.Copy()
+	f1.Add("a")
+	require.False(f2.Contains("a"))
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.Union
+	f1 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f2 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f1.Add("a")
+	f2.Add("a", "b")
+	u, err := f1.Union(f2)
+	require.NoError(err)
+	require.Equal(2, u.Count("a"))
+	require.True(u.Contains("b"))
+	// testdoc end
+
+	_, err = f1.Union(probabilistic.NewCountingBloom(100, 0.001, probabilistic.String()))
+	require.ErrorIs(err, probabilistic.ErrIncompatible)
+}
+
+func (s *CountingBloomSuite) TestMarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.MarshalBinary
+	f := probabilistic.NewCountingBloomWithSize(128, 2, probabilistic.String())
+	data, err := f.MarshalBinary()
+	require.NoError(err)
+	require.Len(data, 3+4+8+64)
+	// testdoc end
+}
+
+func (s *CountingBloomSuite) TestUnmarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin CountingBloom.UnmarshalBinary
+	f1 := probabilistic.NewCountingBloom(100, 0.01, probabilistic.String())
+	f1.Add("a")
+	data, err := f1.MarshalBinary()
+	require.NoError(err)
+
+	f2 := probabilistic.NewCountingBloom(1, 0.5, probabilistic.String())
+	err = f2.UnmarshalBinary(data)
+	require.NoError(err)
+	require.True(f2.Remove("a"))
+	// testdoc end
+	require.True(f1.Contains("a")) // decoded counters are not shared
+}
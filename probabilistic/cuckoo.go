@@ -0,0 +1,426 @@
+package probabilistic
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*Cuckoo[string])(nil)
+	_ encoding.BinaryUnmarshaler = (*Cuckoo[string])(nil)
+)
+
+const (
+	cuckooMagic = "CF"
+	bucketSize  = 4
+	// maxKicks bounds the number of relocations tried before an insertion gives up.
+	maxKicks = 500
+)
+
+// bucket holds up to bucketSize fingerprints. A zero fingerprint marks an empty slot.
+type bucket [bucketSize]uint16
+
+// victim holds a fingerprint that could not be relocated, so that no element is lost
+// when the filter becomes full.
+type victim struct {
+	index uint64
+	fp    uint16
+	used  bool
+}
+
+// Cuckoo is a cuckoo filter: a table of 16-bit fingerprints, each stored in one of two candidate buckets.
+// Unlike a Bloom filter it supports removal, and it is more compact at low false positive rates.
+// Its false positive rate is about 0.01%.
+//
+// A Cuckoo must be created with NewCuckoo.
+type Cuckoo[T any] struct {
+	buckets []bucket
+	count   int
+	victim  victim
+	hash    HashFunc[T]
+}
+
+// NewCuckoo creates a cuckoo filter with room for at least n elements.
+// The number of buckets is rounded up to a power of two.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(1000, probabilistic.String())
+//	require.Equal(2048, f.Cap())
+//	require.Equal(0, f.Len())
+func NewCuckoo[T any](n int, hash HashFunc[T]) *Cuckoo[T] {
+	numBuckets := 1 << bits.Len(uint((max(n, 1)+bucketSize-1)/bucketSize-1))
+	// Insertions start failing at about 95% load, so leave some headroom.
+	if float64(n)/float64(numBuckets*bucketSize) > 0.9 {
+		numBuckets *= 2
+	}
+	return &Cuckoo[T]{
+		buckets: make([]bucket, numBuckets),
+		hash:    hash,
+	}
+}
+
+// UnmarshalCuckoo decodes a cuckoo filter encoded by MarshalBinary.
+// hash must be the HashFunc the filter was built with.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(100, probabilistic.String())
+//	require.NoError(f.Add("a"))
+//	data, err := f.MarshalBinary()
+//	require.NoError(err)
+//
+//	decoded, err := probabilistic.UnmarshalCuckoo(data, probabilistic.String())
+//	require.NoError(err)
+//	require.True(decoded.Contains("a"))
+func UnmarshalCuckoo[T any](data []byte, hash HashFunc[T]) (*Cuckoo[T], error) {
+	c := &Cuckoo[T]{hash: hash}
+	if err := c.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Add inserts the element into the filter.
+// Adding the same element twice stores it twice, so it must also be removed twice.
+// Returns ErrFilterFull if there is no room left.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(100, probabilistic.String())
+//	err := f.Add("a")
+//	require.NoError(err)
+//	require.True(f.Contains("a"))
+func (c *Cuckoo[T]) Add(element T) error {
+	i, fp := c.locate(element)
+	if !c.place(i, fp) {
+		return ErrFilterFull
+	}
+	return nil
+}
+
+// Remove deletes one occurrence of the element from the filter.
+// It returns false if the element is definitely not present.
+// Removing an element that was never added may remove another element with the same fingerprint.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(100, probabilistic.String())
+//	_ = f.Add("a")
+//	require.True(f.Remove("a"))
+//	require.False(f.Contains("a"))
+//	require.False(f.Remove("a"))
+func (c *Cuckoo[T]) Remove(element T) bool {
+	i1, fp := c.locate(element)
+	i2 := c.altIndex(i1, fp)
+	if !c.delete(i1, fp) && !c.delete(i2, fp) {
+		if c.victim.used && c.victim.fp == fp && (c.victim.index == i1 || c.victim.index == i2) {
+			c.victim = victim{}
+			c.count--
+			return true
+		}
+		return false
+	}
+	c.count--
+	// A slot has been freed, so try to move the victim back into the table.
+	if v := c.victim; v.used {
+		c.victim = victim{}
+		c.count--
+		c.place(v.index, v.fp)
+	}
+	return true
+}
+
+// Contains reports whether the element may be in the filter.
+// A false result is definite; a true result is wrong with the filter's false positive rate.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(100, probabilistic.String())
+//	_ = f.Add("a")
+//	require.True(f.Contains("a"))
+//	require.False(f.Contains("z"))
+func (c *Cuckoo[T]) Contains(element T) bool {
+	i1, fp := c.locate(element)
+	i2 := c.altIndex(i1, fp)
+	if c.buckets[i1].contains(fp) || c.buckets[i2].contains(fp) {
+		return true
+	}
+	return c.victim.used && c.victim.fp == fp && (c.victim.index == i1 || c.victim.index == i2)
+}
+
+// Len returns the number of stored fingerprints.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(100, probabilistic.String())
+//	_ = f.Add("a")
+//	_ = f.Add("a")
+//	_ = f.Add("b")
+//	require.Equal(3, f.Len())
+func (c *Cuckoo[T]) Len() int {
+	return c.count
+}
+
+// Cap returns the number of fingerprint slots in the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(10, probabilistic.String())
+//	require.Equal(16, f.Cap())
+func (c *Cuckoo[T]) Cap() int {
+	return len(c.buckets) * bucketSize
+}
+
+// LoadFactor returns the fraction of slots in use.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(10, probabilistic.String())
+//	for i := range 4 {
+//		_ = f.Add(fmt.Sprint(i))
+//	}
+//	require.Equal(0.25, f.LoadFactor())
+func (c *Cuckoo[T]) LoadFactor() float64 {
+	return float64(c.count) / float64(c.Cap())
+}
+
+// IsEmpty checks if the filter holds no fingerprints.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(100, probabilistic.String())
+//	require.True(f.IsEmpty())
+//	_ = f.Add("a")
+//	require.False(f.IsEmpty())
+func (c *Cuckoo[T]) IsEmpty() bool {
+	return c.count == 0
+}
+
+// Clear removes all elements from the filter.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(100, probabilistic.String())
+//	_ = f.Add("a")
+//	f.Clear()
+//	require.False(f.Contains("a"))
+//	require.True(f.IsEmpty())
+func (c *Cuckoo[T]) Clear() {
+	clear(c.buckets)
+	c.count = 0
+	c.victim = victim{}
+}
+
+// Copy creates a copy of the filter.
+//
+// Example:
+//
+//	f1 := probabilistic.NewCuckoo(100, probabilistic.String())
+//	f2 := f1.Copy()
+//	_ = f1.Add("a")
+//	require.False(f2.Contains("a"))
+func (c *Cuckoo[T]) Copy() *Cuckoo[T] {
+	result := *c
+	result.buckets = append([]bucket(nil), c.buckets...)
+	return &result
+}
+
+// Union creates a new filter containing the fingerprints of both filters.
+// Returns ErrIncompatible if the filters have different capacities,
+// and ErrFilterFull if the result cannot hold every fingerprint.
+// Both filters must use the same HashFunc.
+//
+// Example:
+//
+//	f1 := probabilistic.NewCuckoo(100, probabilistic.String())
+//	f2 := probabilistic.NewCuckoo(100, probabilistic.String())
+//	_ = f1.Add("a")
+//	_ = f2.Add("b")
+//	u, err := f1.Union(f2)
+//	require.NoError(err)
+//	require.True(u.Contains("a"))
+//	require.True(u.Contains("b"))
+//	require.Equal(2, u.Len())
+func (c *Cuckoo[T]) Union(other *Cuckoo[T]) (*Cuckoo[T], error) {
+	if len(c.buckets) != len(other.buckets) {
+		return nil, fmt.Errorf("%w: cuckoo filters with %d and %d buckets",
+			ErrIncompatible, len(c.buckets), len(other.buckets))
+	}
+	result := c.Copy()
+	for i, b := range other.buckets {
+		for _, fp := range b {
+			if fp != 0 && !result.place(uint64(i), fp) {
+				return nil, ErrFilterFull
+			}
+		}
+	}
+	if v := other.victim; v.used && !result.place(v.index, v.fp) {
+		return nil, ErrFilterFull
+	}
+	return result, nil
+}
+
+// MarshalBinary encodes the filter's fingerprint table.
+// The hash function is not encoded.
+//
+// Example:
+//
+//	f := probabilistic.NewCuckoo(8, probabilistic.String())
+//	data, err := f.MarshalBinary()
+//	require.NoError(err)
+//	require.Len(data, 3+4+8+11+4*4*2)
+func (c *Cuckoo[T]) MarshalBinary() ([]byte, error) {
+	data := appendHeader(make([]byte, 0, 26+len(c.buckets)*bucketSize*2), cuckooMagic)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(c.buckets)))
+	data = binary.LittleEndian.AppendUint64(data, uint64(c.count))
+	if c.victim.used {
+		data = append(data, 1)
+	} else {
+		data = append(data, 0)
+	}
+	data = binary.LittleEndian.AppendUint64(data, c.victim.index)
+	data = binary.LittleEndian.AppendUint16(data, c.victim.fp)
+	for _, b := range c.buckets {
+		for _, fp := range b {
+			data = binary.LittleEndian.AppendUint16(data, fp)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary, replacing its contents.
+// The filter keeps its HashFunc, which must be the one the encoded filter was built with.
+//
+// Example:
+//
+//	f1 := probabilistic.NewCuckoo(100, probabilistic.String())
+//	_ = f1.Add("a")
+//	data, err := f1.MarshalBinary()
+//	require.NoError(err)
+//
+//	f2 := probabilistic.NewCuckoo(1, probabilistic.String())
+//	err = f2.UnmarshalBinary(data)
+//	require.NoError(err)
+//	require.True(f2.Contains("a"))
+func (c *Cuckoo[T]) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, cuckooMagic)
+	if err != nil {
+		return err
+	}
+	if len(data) < 23 {
+		return fmt.Errorf("%w: truncated cuckoo filter", ErrInvalidFormat)
+	}
+	numBuckets := uint64(binary.LittleEndian.Uint32(data))
+	count := binary.LittleEndian.Uint64(data[4:])
+	v := victim{
+		used:  data[12] == 1,
+		index: binary.LittleEndian.Uint64(data[13:]),
+		fp:    binary.LittleEndian.Uint16(data[21:]),
+	}
+	data = data[23:]
+	if numBuckets == 0 || numBuckets&(numBuckets-1) != 0 || uint64(len(data)) != numBuckets*bucketSize*2 {
+		return fmt.Errorf("%w: cuckoo filter with %d buckets and %d bytes", ErrInvalidFormat, numBuckets, len(data))
+	}
+	if v.used && (v.index >= numBuckets || v.fp == 0) {
+		return fmt.Errorf("%w: invalid victim", ErrInvalidFormat)
+	}
+	buckets := make([]bucket, numBuckets)
+	var stored uint64
+	for i := range buckets {
+		for j := range buckets[i] {
+			fp := binary.LittleEndian.Uint16(data[2*(i*bucketSize+j):])
+			buckets[i][j] = fp
+			if fp != 0 {
+				stored++
+			}
+		}
+	}
+	if v.used {
+		stored++
+	}
+	if stored != count {
+		return fmt.Errorf("%w: count %d does not match %d stored fingerprints", ErrInvalidFormat, count, stored)
+	}
+	c.buckets, c.count, c.victim = buckets, int(count), v
+	return nil
+}
+
+// locate returns the primary bucket index and the fingerprint of an element.
+func (c *Cuckoo[T]) locate(element T) (uint64, uint16) {
+	h := c.hash(element)
+	fp := uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	return h & uint64(len(c.buckets)-1), fp
+}
+
+// altIndex returns the other candidate bucket for a fingerprint stored in bucket i.
+// It is its own inverse, so the alternate of the alternate is i again.
+func (c *Cuckoo[T]) altIndex(i uint64, fp uint16) uint64 {
+	return (i ^ mix64(uint64(fp))) & uint64(len(c.buckets)-1)
+}
+
+// place stores a fingerprint in bucket i or its alternate, relocating existing fingerprints if needed.
+// It returns false without changing the filter if a victim is already pending.
+func (c *Cuckoo[T]) place(i uint64, fp uint16) bool {
+	if c.victim.used {
+		return false
+	}
+	c.count++
+	if c.buckets[i].insert(fp) {
+		return true
+	}
+	if alt := c.altIndex(i, fp); c.buckets[alt].insert(fp) {
+		return true
+	}
+	if rand.IntN(2) == 0 {
+		i = c.altIndex(i, fp)
+	}
+	for range maxKicks {
+		slot := rand.IntN(bucketSize)
+		fp, c.buckets[i][slot] = c.buckets[i][slot], fp
+		i = c.altIndex(i, fp)
+		if c.buckets[i].insert(fp) {
+			return true
+		}
+	}
+	c.victim = victim{index: i, fp: fp, used: true}
+	return true
+}
+
+// delete removes one copy of fp from bucket i.
+func (c *Cuckoo[T]) delete(i uint64, fp uint16) bool {
+	for j, stored := range c.buckets[i] {
+		if stored == fp {
+			c.buckets[i][j] = 0
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bucket) insert(fp uint16) bool {
+	for j, stored := range b {
+		if stored == 0 {
+			b[j] = fp
+			return true
+		}
+	}
+	return false
+}
+
+func (b *bucket) contains(fp uint16) bool {
+	for _, stored := range b {
+		if stored == fp {
+			return true
+		}
+	}
+	return false
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,278 @@
+package probabilistic_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/probabilistic"
+)
+
+type CuckooSuite struct {
+	suite.Suite
+}
+
+func TestCuckooSuite(t *testing.T) {
+	suite.Run(t, new(CuckooSuite))
+}
+
+func (s *CuckooSuite) TestNewCuckoo() {
+	require := require.New(s.T())
+	// testdoc begin NewCuckoo
+	f := probabilistic.NewCuckoo(1000, probabilistic.String())
+	require.Equal(2048, f.Cap())
+	require.Equal(0, f.Len())
+	// testdoc end
+	require.Equal(4, probabilistic.NewCuckoo(0, probabilistic.String()).Cap())
+	require.Equal(8, probabilistic.NewCuckoo(4, probabilistic.String()).Cap())
+}
+
+func (s *CuckooSuite) TestUnmarshalCuckoo() {
+	require := require.New(s.T())
+	// testdoc begin UnmarshalCuckoo
+	f := probabilistic.NewCuckoo(100, probabilistic.String())
+	require.NoError(f.Add("a"))
+	data, err := f.MarshalBinary()
+	require.NoError(err)
+
+	decoded, err := probabilistic.UnmarshalCuckoo(data, probabilistic.String())
+	require.NoError(err)
+	require.True(decoded.Contains("a"))
+	// testdoc end
+	require.Equal(1, decoded.Len())
+	require.Equal(f.Cap(), decoded.Cap())
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("BF\x01"),
+		data[:20],
+		data[:len(data)-2],
+	} {
+		_, err := probabilistic.UnmarshalCuckoo(bad, probabilistic.String())
+		require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+	}
+
+	// count must match the stored fingerprints
+	corrupt := append([]byte(nil), data...)
+	corrupt[7] = 2
+	_, err = probabilistic.UnmarshalCuckoo(corrupt, probabilistic.String())
+	require.ErrorIs(err, probabilistic.ErrInvalidFormat)
+}
+
+func (s *CuckooSuite) TestAdd() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Add
+	f := probabilistic.NewCuckoo(100, probabilistic.String())
+	err := f.Add("a")
+	require.NoError(err)
+	require.True(f.Contains("a"))
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestFull() {
+	require := require.New(s.T())
+	f := probabilistic.NewCuckoo(16, probabilistic.Integer[int]())
+	added := 0
+	var err error
+	for i := 0; err == nil; i++ {
+		if err = f.Add(i); err == nil {
+			added++
+		}
+	}
+	require.ErrorIs(err, probabilistic.ErrFilterFull)
+	require.Equal(added, f.Len())
+	require.LessOrEqual(added, f.Cap()+1)
+	for i := range added {
+		require.True(f.Contains(i)) // nothing was lost, including the pending victim
+	}
+
+	// removing an element makes room again
+	require.True(f.Remove(0))
+	require.NoError(f.Add(-1))
+}
+
+func (s *CuckooSuite) TestMany() {
+	require := require.New(s.T())
+	const n = 10000
+	f := probabilistic.NewCuckoo(n, probabilistic.String())
+	for i := range n {
+		require.NoError(f.Add(fmt.Sprint("in-", i)))
+	}
+	for i := range n {
+		require.True(f.Contains(fmt.Sprint("in-", i)))
+	}
+	fp := 0
+	for i := range n {
+		if f.Contains(fmt.Sprint("out-", i)) {
+			fp++
+		}
+	}
+	require.Less(float64(fp)/n, 0.001)
+
+	for i := 0; i < n; i += 2 {
+		require.True(f.Remove(fmt.Sprint("in-", i)))
+	}
+	for i := 1; i < n; i += 2 {
+		require.True(f.Contains(fmt.Sprint("in-", i)))
+	}
+	require.Equal(n/2, f.Len())
+}
+
+func (s *CuckooSuite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Remove
+	f := probabilistic.NewCuckoo(100, probabilistic.String())
+	_ = f.Add("a")
+	require.True(f.Remove("a"))
+	require.False(f.Contains("a"))
+	require.False(f.Remove("a"))
+	// testdoc end
+
+	require.NoError(f.Add("b"))
+	require.NoError(f.Add("b"))
+	require.True(f.Remove("b"))
+	require.True(f.Contains("b")) // added twice, removed once
+}
+
+func (s *CuckooSuite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Contains
+	f := probabilistic.NewCuckoo(100, probabilistic.String())
+	_ = f.Add("a")
+	require.True(f.Contains("a"))
+	require.False(f.Contains("z"))
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Len
+	f := probabilistic.NewCuckoo(100, probabilistic.String())
+	_ = f.Add("a")
+	_ = f.Add("a")
+	_ = f.Add("b")
+	require.Equal(3, f.Len())
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestCap() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Cap
+	f := probabilistic.NewCuckoo(10, probabilistic.String())
+	require.Equal(16, f.Cap())
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestLoadFactor() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.LoadFactor
+	f := probabilistic.NewCuckoo(10, probabilistic.String())
+	for i := range 4 {
+		_ = f.Add(fmt.Sprint(i))
+	}
+	require.Equal(0.25, f.LoadFactor())
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.IsEmpty
+	f := probabilistic.NewCuckoo(100, probabilistic.String())
+	require.True(f.IsEmpty())
+	_ = f.Add("a")
+	require.False(f.IsEmpty())
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Clear
+	f := probabilistic.NewCuckoo(100, probabilistic.String())
+	_ = f.Add("a")
+	f.Clear()
+	require.False(f.Contains("a"))
+	require.True(f.IsEmpty())
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Copy
+	f1 := probabilistic.NewCuckoo(100, probabilistic.String())
+	f2 := f1.Copy()
+	_ = f1.Add("a")
+	require.False(f2.Contains("a"))
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.Union
+	f1 := probabilistic.NewCuckoo(100, probabilistic.String())
+	f2 := probabilistic.NewCuckoo(100, probabilistic.String())
+	_ = f1.Add("a")
+	_ = f2.Add("b")
+	u, err := f1.Union(f2)
+	require.NoError(err)
+	require.True(u.Contains("a"))
+	require.True(u.Contains("b"))
+	require.Equal(2, u.Len())
+	// testdoc end
+	require.False(f1.Contains("b")) // f1 should be unchanged
+
+	_, err = f1.Union(probabilistic.NewCuckoo(1000, probabilistic.String()))
+	require.ErrorIs(err, probabilistic.ErrIncompatible)
+
+	// the union of two filters filled to capacity cannot fit
+	full1 := probabilistic.NewCuckoo(16, probabilistic.Integer[int]())
+	full2 := probabilistic.NewCuckoo(16, probabilistic.Integer[int]())
+	for i := range 28 {
+		require.NoError(full1.Add(i))
+		require.NoError(full2.Add(i + 100))
+	}
+	_, err = full1.Union(full2)
+	require.ErrorIs(err, probabilistic.ErrFilterFull)
+}
+
+func (s *CuckooSuite) TestMarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.MarshalBinary
+	f := probabilistic.NewCuckoo(8, probabilistic.String())
+	data, err := f.MarshalBinary()
+	require.NoError(err)
+	require.Len(data, 3+4+8+11+4*4*2)
+	// testdoc end
+}
+
+func (s *CuckooSuite) TestUnmarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin Cuckoo.UnmarshalBinary
+	f1 := probabilistic.NewCuckoo(100, probabilistic.String())
+	_ = f1.Add("a")
+	data, err := f1.MarshalBinary()
+	require.NoError(err)
+
+	f2 := probabilistic.NewCuckoo(1, probabilistic.String())
+	err = f2.UnmarshalBinary(data)
+	require.NoError(err)
+	require.True(f2.Contains("a"))
+	// testdoc end
+
+	// a pending victim survives a round trip
+	full := probabilistic.NewCuckoo(4, probabilistic.Integer[int]())
+	n := 0
+	for full.Add(n) == nil {
+		n++
+	}
+	data, err = full.MarshalBinary()
+	require.NoError(err)
+	decoded, err := probabilistic.UnmarshalCuckoo(data, probabilistic.Integer[int]())
+	require.NoError(err)
+	require.Equal(full.Len(), decoded.Len())
+	for i := range n {
+		require.True(decoded.Contains(i))
+	}
+	require.ErrorIs(decoded.Add(n), probabilistic.ErrFilterFull)
+}
@@ -0,0 +1,150 @@
+// Package probabilistic provides space-efficient approximate set membership filters.
+//
+// Filters answer "possibly in the set" or "definitely not in the set": false positives
+// occur at a configurable rate, false negatives never do.
+package probabilistic
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+)
+
+// ErrInvalidFormat is returned when decoding data that was not produced by the matching MarshalBinary.
+var ErrInvalidFormat = errors.New("probabilistic: invalid format")
+
+// ErrIncompatible is returned when combining filters with different parameters.
+var ErrIncompatible = errors.New("probabilistic: incompatible filters")
+
+// ErrFilterFull is returned when a cuckoo filter cannot store any more elements.
+var ErrFilterFull = errors.New("probabilistic: filter is full")
+
+// HashFunc maps an element to a 64-bit hash.
+// Filters derive all of their positions from this single hash, so it should be well distributed.
+// A filter must be decoded and combined using the same HashFunc it was built with.
+type HashFunc[T any] func(T) uint64
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// String returns a HashFunc for strings.
+// The hash does not depend on the process, so filters built with it can be serialized and shared.
+//
+// Example:
+//
+//	h := probabilistic.String()
+//	require.Equal(h("hello"), probabilistic.String()("hello"))
+//	require.NotEqual(h("hello"), h("world"))
+func String() HashFunc[string] {
+	return func(s string) uint64 {
+		h := uint64(fnvOffset)
+		for i := 0; i < len(s); i++ {
+			h ^= uint64(s[i])
+			h *= fnvPrime
+		}
+		return mix64(h)
+	}
+}
+
+// Bytes returns a HashFunc for byte slices that agrees with String on the same content.
+// The hash does not depend on the process, so filters built with it can be serialized and shared.
+//
+// Example:
+//
+//	h := probabilistic.Bytes()
+//	require.Equal(probabilistic.String()("hello"), h([]byte("hello")))
+func Bytes() HashFunc[[]byte] {
+	return func(b []byte) uint64 {
+		h := uint64(fnvOffset)
+		for _, c := range b {
+			h ^= uint64(c)
+			h *= fnvPrime
+		}
+		return mix64(h)
+	}
+}
+
+// Integer returns a HashFunc for integer types.
+// The hash does not depend on the process, so filters built with it can be serialized and shared.
+//
+// Example:
+//
+//	h := probabilistic.Integer[int64]()
+//	require.Equal(h(42), probabilistic.Integer[int64]()(42))
+//	require.NotEqual(h(1), h(2))
+func Integer[T integer]() HashFunc[T] {
+	return func(v T) uint64 {
+		return mix64(uint64(v))
+	}
+}
+
+// Comparable returns a HashFunc for any comparable type using hash/maphash.
+// The hash depends on seed, so filters built with it can only be decoded
+// by a process that uses the same seed value.
+//
+// Example:
+//
+//	type Pos struct{ X, Y int }
+//	h := probabilistic.Comparable[Pos](maphash.MakeSeed())
+//	f := probabilistic.NewBloom(100, 0.01, h)
+//	f.Add(Pos{1, 2})
+//	require.True(f.Contains(Pos{1, 2}))
+func Comparable[T comparable](seed maphash.Seed) HashFunc[T] {
+	return func(v T) uint64 {
+		return maphash.Comparable(seed, v)
+	}
+}
+
+const (
+	fnvOffset = 14695981039346656037
+	fnvPrime  = 1099511628211
+)
+
+// mix64 is the splitmix64 finalizer, used to spread entropy over all bits of a hash.
+func mix64(z uint64) uint64 {
+	z ^= z >> 30
+	z *= 0xbf58476d1ce4e5b9
+	z ^= z >> 27
+	z *= 0x94d049bb133111eb
+	z ^= z >> 31
+	return z
+}
+
+// MaxHashCount is the largest number of hash functions a Bloom or counting Bloom filter may use.
+// Beyond it the false positive rate is already below 2^-64, so more hashing only costs time.
+const MaxHashCount = 64
+
+// locations calls fn with the k positions of an element hash in a table of m slots.
+// Positions are derived by double hashing so that only one element hash is needed.
+func locations(h uint64, k uint32, m uint64, fn func(uint64) bool) bool {
+	step := mix64(h) | 1
+	for i := range uint64(k) {
+		if !fn((h + i*step) % m) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatVersion is the version byte written after the magic of every encoded filter.
+const formatVersion = 1
+
+// appendHeader appends the magic and format version that start an encoded filter.
+func appendHeader(data []byte, magic string) []byte {
+	return append(append(data, magic...), formatVersion)
+}
+
+// readHeader checks the magic and format version of an encoded filter and returns the rest of the data.
+func readHeader(data []byte, magic string) ([]byte, error) {
+	if len(data) < len(magic)+1 || string(data[:len(magic)]) != magic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalidFormat)
+	}
+	if v := data[len(magic)]; v != formatVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidFormat, v)
+	}
+	return data[len(magic)+1:], nil
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
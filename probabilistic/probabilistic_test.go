@@ -0,0 +1,68 @@
+package probabilistic_test
+
+import (
+	"hash/maphash"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/probabilistic"
+)
+
+type HashSuite struct {
+	suite.Suite
+}
+
+func TestHashSuite(t *testing.T) {
+	suite.Run(t, new(HashSuite))
+}
+
+func (s *HashSuite) TestString() {
+	require := require.New(s.T())
+	// testdoc begin String
+	h := probabilistic.String()
+	require.Equal(h("hello"), probabilistic.String()("hello"))
+	require.NotEqual(h("hello"), h("world"))
+	// testdoc end
+
+	// the hash is stable across processes so encoded filters stay valid
+	require.Equal(uint64(0xf52a15e9a9b5e89b), h(""))
+}
+
+func (s *HashSuite) TestBytes() {
+	require := require.New(s.T())
+	// testdoc begin Bytes
+	h := probabilistic.Bytes()
+	require.Equal(probabilistic.String()("hello"), h([]byte("hello")))
+	// testdoc end
+	require.Equal(h(nil), h([]byte{}))
+}
+
+func (s *HashSuite) TestInteger() {
+	require := require.New(s.T())
+	// testdoc begin Integer
+	h := probabilistic.Integer[int64]()
+	require.Equal(h(42), probabilistic.Integer[int64]()(42))
+	require.NotEqual(h(1), h(2))
+	// testdoc end
+
+	// consecutive integers spread over the high bits as well
+	high := map[uint64]bool{}
+	for i := range 64 {
+		high[h(int64(i))>>60] = true
+	}
+	require.Greater(len(high), 8)
+}
+
+func (s *HashSuite) TestComparable() {
+	require := require.New(s.T())
+	// testdoc begin Comparable
+	type Pos struct{ X, Y int }
+	h := probabilistic.Comparable[Pos](maphash.MakeSeed())
+	f := probabilistic.NewBloom(100, 0.01, h)
+	f.Add(Pos{1, 2})
+	require.True(f.Contains(Pos{1, 2}))
+	// testdoc end
+	require.Equal(h(Pos{3, 4}), h(Pos{3, 4}))
+}
@@ -0,0 +1,426 @@
+// Package roaring provides compressed bitmaps for sets of uint32 values.
+//
+// A Bitmap splits each value into its high and low 16 bits. Values sharing the same
+// high bits are stored together in a container, which is either a sorted array
+// (sparse), a 2^16-bit bitmap (dense) or a list of runs (consecutive values).
+// The binary serialization follows the portable Roaring format specification
+// (https://github.com/RoaringBitmap/RoaringFormatSpec), so bitmaps can be exchanged
+// with other Roaring implementations.
+package roaring
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+// Bitmap is a compressed set of uint32 values.
+type Bitmap struct {
+	// keys holds the high 16 bits of each container in ascending order.
+	keys       []uint16
+	containers []container
+}
+
+// New creates a new Bitmap and initializes it with the provided values.
+//
+// Example:
+//
+//	b1 := roaring.New()
+//	b2 := roaring.New(3, 1, 1<<20)
+//	require.True(b1.IsEmpty())
+//	require.Equal([]uint32{1, 3, 1 << 20}, b2.ToSlice())
+func New(values ...uint32) *Bitmap {
+	b := &Bitmap{}
+	b.Add(values...)
+	return b
+}
+
+// FromSet creates a new Bitmap containing the elements of s.
+//
+// Example:
+//
+//	b := roaring.FromSet(set.New[uint32](3, 1, 2))
+//	require.Equal([]uint32{1, 2, 3}, b.ToSlice())
+func FromSet(s set.Set[uint32]) *Bitmap {
+	values := s.ToSlice()
+	slices.Sort(values)
+	return New(values...)
+}
+
+// ToSet converts the Bitmap to a set.Set.
+//
+// Example:
+//
+//	b := roaring.New(1, 2, 3)
+//	require.True(b.ToSet().Equals(set.New[uint32](1, 2, 3)))
+func (b *Bitmap) ToSet() set.Set[uint32] {
+	s := set.New[uint32]()
+	for v := range b.All() {
+		s.Insert(v)
+	}
+	return s
+}
+
+func split(v uint32) (high, low uint16) {
+	return uint16(v >> 16), uint16(v)
+}
+
+func join(high, low uint16) uint32 {
+	return uint32(high)<<16 | uint32(low)
+}
+
+// find returns the index of the container with the given key and whether it exists.
+func (b *Bitmap) find(key uint16) (int, bool) {
+	return slices.BinarySearch(b.keys, key)
+}
+
+// Add adds one or more values to the bitmap.
+//
+// Example:
+//
+//	b := roaring.New()
+//	b.Add(5)
+//	b.Add(1, 70000, 5)
+//	require.Equal([]uint32{1, 5, 70000}, b.ToSlice())
+func (b *Bitmap) Add(values ...uint32) {
+	for _, v := range values {
+		high, low := split(v)
+		i, found := b.find(high)
+		if !found {
+			b.keys = slices.Insert(b.keys, i, high)
+			b.containers = slices.Insert(b.containers, i, container(&arrayContainer{}))
+		}
+		b.containers[i] = b.containers[i].add(low)
+	}
+}
+
+// Remove deletes one or more values from the bitmap.
+//
+// Example:
+//
+//	b := roaring.New(1, 2, 70000)
+//	b.Remove(2)
+//	b.Remove(70000, 99)
+//	require.Equal([]uint32{1}, b.ToSlice())
+func (b *Bitmap) Remove(values ...uint32) {
+	for _, v := range values {
+		high, low := split(v)
+		i, found := b.find(high)
+		if !found {
+			continue
+		}
+		b.containers[i] = b.containers[i].remove(low)
+		if b.containers[i].cardinality() == 0 {
+			b.keys = slices.Delete(b.keys, i, i+1)
+			b.containers = slices.Delete(b.containers, i, i+1)
+		}
+	}
+}
+
+// Contains checks if the bitmap contains a specific value.
+//
+// Example:
+//
+//	b := roaring.New(1, 1<<31)
+//	require.True(b.Contains(1 << 31))
+//	require.False(b.Contains(2))
+func (b *Bitmap) Contains(value uint32) bool {
+	high, low := split(value)
+	i, found := b.find(high)
+	return found && b.containers[i].contains(low)
+}
+
+// Cardinality returns the number of values in the bitmap.
+//
+// Example:
+//
+//	b := roaring.New(1, 2, 2, 1<<20)
+//	require.Equal(uint64(3), b.Cardinality())
+func (b *Bitmap) Cardinality() uint64 {
+	var n uint64
+	for _, c := range b.containers {
+		n += uint64(c.cardinality())
+	}
+	return n
+}
+
+// IsEmpty checks if the bitmap is empty.
+//
+// Example:
+//
+//	b := roaring.New()
+//	require.True(b.IsEmpty())
+//	b.Add(1)
+//	require.False(b.IsEmpty())
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.containers) == 0
+}
+
+// Equals checks if two bitmaps contain the same values, regardless of their internal representation.
+//
+// Example:
+//
+//	b1 := roaring.New(dense(0, 10000)...)
+//	b2 := b1.Copy()
+//	b2.RunOptimize()
+//	require.True(b1.Equals(b2)) // representation does not matter
+//	require.False(b1.Equals(roaring.New(1)))
+func (b *Bitmap) Equals(other *Bitmap) bool {
+	if !slices.Equal(b.keys, other.keys) {
+		return false
+	}
+	for i, c := range b.containers {
+		if !equalContainers(c, other.containers[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Rank returns the number of values less than or equal to x.
+//
+// Example:
+//
+//	b := roaring.New(10, 20, 1<<20)
+//	require.Equal(uint64(0), b.Rank(5))
+//	require.Equal(uint64(1), b.Rank(10))
+//	require.Equal(uint64(2), b.Rank(1000))
+//	require.Equal(uint64(3), b.Rank(1<<30))
+func (b *Bitmap) Rank(x uint32) uint64 {
+	high, low := split(x)
+	var n uint64
+	for i, key := range b.keys {
+		if key > high {
+			break
+		}
+		if key < high {
+			n += uint64(b.containers[i].cardinality())
+		} else {
+			n += uint64(b.containers[i].rank(low))
+		}
+	}
+	return n
+}
+
+// Select returns the value at zero-based index i in ascending order.
+// The second result is false if i is out of range.
+//
+// Example:
+//
+//	b := roaring.New(10, 20, 1<<20)
+//	v, ok := b.Select(2)
+//	require.True(ok)
+//	require.Equal(uint32(1<<20), v)
+//	_, ok = b.Select(3)
+//	require.False(ok)
+func (b *Bitmap) Select(i uint64) (uint32, bool) {
+	for k, c := range b.containers {
+		card := uint64(c.cardinality())
+		if i >= card {
+			i -= card
+			continue
+		}
+		return join(b.keys[k], c.selectAt(int(i))), true
+	}
+	return 0, false
+}
+
+// Min returns the smallest value in the bitmap.
+// The second result is false if the bitmap is empty.
+//
+// Example:
+//
+//	v, ok := roaring.New(7, 3, 1<<20).Min()
+//	require.True(ok)
+//	require.Equal(uint32(3), v)
+func (b *Bitmap) Min() (uint32, bool) {
+	return b.Select(0)
+}
+
+// Max returns the largest value in the bitmap.
+// The second result is false if the bitmap is empty.
+//
+// Example:
+//
+//	v, ok := roaring.New(7, 3, 1<<20).Max()
+//	require.True(ok)
+//	require.Equal(uint32(1<<20), v)
+func (b *Bitmap) Max() (uint32, bool) {
+	if b.IsEmpty() {
+		return 0, false
+	}
+	last := len(b.containers) - 1
+	c := b.containers[last]
+	return join(b.keys[last], c.selectAt(c.cardinality()-1)), true
+}
+
+// All returns an iterator over the values of the bitmap in ascending order.
+//
+// Example:
+//
+//	b := roaring.New(1<<20, 2, 1)
+//	var got []uint32
+//	for v := range b.All() {
+//		got = append(got, v)
+//	}
+//	require.Equal([]uint32{1, 2, 1 << 20}, got)
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range b.containers {
+			high := b.keys[i]
+			if !c.all(func(low uint16) bool { return yield(join(high, low)) }) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the values of the bitmap in ascending order.
+//
+// Example:
+//
+//	b := roaring.New(9, 4, 6)
+//	require.Equal([]uint32{4, 6, 9}, b.ToSlice())
+func (b *Bitmap) ToSlice() []uint32 {
+	values := make([]uint32, 0, b.Cardinality())
+	for v := range b.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Clear removes all values from the bitmap.
+//
+// Example:
+//
+//	b := roaring.New(1, 2)
+//	b.Clear()
+//	require.True(b.IsEmpty())
+func (b *Bitmap) Clear() {
+	b.keys = nil
+	b.containers = nil
+}
+
+// Copy creates a deep copy of the bitmap.
+//
+// Example:
+//
+//	b1 := roaring.New(1, 2)
+//	b2 := b1.Copy()
+//	b1.Add(3)
+//	require.Equal([]uint32{1, 2}, b2.ToSlice())
+func (b *Bitmap) Copy() *Bitmap {
+	result := &Bitmap{
+		keys:       slices.Clone(b.keys),
+		containers: make([]container, len(b.containers)),
+	}
+	for i, c := range b.containers {
+		result.containers[i] = c.clone()
+	}
+	return result
+}
+
+// RunOptimize converts each container to run-length encoding when that is smaller,
+// and converts run containers back when it is not.
+// It is typically called once before serializing a bitmap with long runs of consecutive values.
+//
+// Example:
+//
+//	b := roaring.New(dense(0, 60000)...)
+//	before, _ := b.MarshalBinary()
+//	b.RunOptimize()
+//	after, _ := b.MarshalBinary()
+//	require.Less(len(after), len(before))
+//	require.Equal(uint64(60000), b.Cardinality())
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// Union creates a new bitmap that is the union of the current bitmap and another bitmap.
+//
+// Example:
+//
+//	b1 := roaring.New(1, 2, 3)
+//	b2 := roaring.New(3, 4, 1<<20)
+//	require.Equal([]uint32{1, 2, 3, 4, 1 << 20}, b1.Union(b2).ToSlice())
+//	require.Equal([]uint32{1, 2, 3}, b1.ToSlice()) // b1 should be unchanged
+func (b *Bitmap) Union(other *Bitmap) *Bitmap {
+	return b.combine(other, true, union, true)
+}
+
+// Intersection creates a new bitmap that is the intersection of the current bitmap and another bitmap.
+//
+// Example:
+//
+//	b1 := roaring.New(1, 2, 3, 4)
+//	b2 := roaring.New(3, 4, 5, 6)
+//	require.Equal([]uint32{3, 4}, b1.Intersection(b2).ToSlice())
+func (b *Bitmap) Intersection(other *Bitmap) *Bitmap {
+	return b.combine(other, false, intersection, false)
+}
+
+// Difference creates a new bitmap that contains values in the current bitmap but not in the other bitmap.
+//
+// Example:
+//
+//	b1 := roaring.New(1, 2, 3, 4)
+//	b2 := roaring.New(3, 4, 5, 6)
+//	require.Equal([]uint32{1, 2}, b1.Difference(b2).ToSlice())
+func (b *Bitmap) Difference(other *Bitmap) *Bitmap {
+	return b.combine(other, true, difference, false)
+}
+
+// SymmetricDifference creates a new bitmap with values in either bitmap but not in both.
+//
+// Example:
+//
+//	b1 := roaring.New(1, 2, 3, 4)
+//	b2 := roaring.New(3, 4, 5, 6)
+//	require.Equal([]uint32{1, 2, 5, 6}, b1.SymmetricDifference(b2).ToSlice())
+func (b *Bitmap) SymmetricDifference(other *Bitmap) *Bitmap {
+	return b.combine(other, true, symmetricDifference, true)
+}
+
+// combine merges the containers of both bitmaps by key. Containers found only in b or
+// only in other are copied as selected by the flags, and containers sharing a key are
+// combined with op. Empty results are dropped.
+func (b *Bitmap) combine(other *Bitmap, onlyLeft bool, op func(a, b container) container, onlyRight bool) *Bitmap {
+	result := &Bitmap{}
+	push := func(key uint16, c container) {
+		if c.cardinality() > 0 {
+			result.keys = append(result.keys, key)
+			result.containers = append(result.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(other.keys) {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			if onlyLeft {
+				push(b.keys[i], b.containers[i].clone())
+			}
+			i++
+		case b.keys[i] > other.keys[j]:
+			if onlyRight {
+				push(other.keys[j], other.containers[j].clone())
+			}
+			j++
+		default:
+			push(b.keys[i], op(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	for ; onlyLeft && i < len(b.keys); i++ {
+		push(b.keys[i], b.containers[i].clone())
+	}
+	for ; onlyRight && j < len(other.keys); j++ {
+		push(other.keys[j], other.containers[j].clone())
+	}
+	return result
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
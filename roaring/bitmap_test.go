@@ -0,0 +1,376 @@
+package roaring_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/roaring"
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+// dense returns n consecutive values starting at start.
+func dense(start uint32, n int) []uint32 {
+	values := make([]uint32, n)
+	for i := range values {
+		values[i] = start + uint32(i)
+	}
+	return values
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	b1 := roaring.New()
+	b2 := roaring.New(3, 1, 1<<20)
+	require.True(b1.IsEmpty())
+	require.Equal([]uint32{1, 3, 1 << 20}, b2.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestFromSet() {
+	require := require.New(s.T())
+	// testdoc begin FromSet
+	b := roaring.FromSet(set.New[uint32](3, 1, 2))
+	require.Equal([]uint32{1, 2, 3}, b.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestToSet() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.ToSet
+	b := roaring.New(1, 2, 3)
+	require.True(b.ToSet().Equals(set.New[uint32](1, 2, 3)))
+	// testdoc end
+}
+
+func (s *Suite) TestAdd() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Add
+	b := roaring.New()
+	b.Add(5)
+	b.Add(1, 70000, 5)
+	require.Equal([]uint32{1, 5, 70000}, b.ToSlice())
+	// testdoc end
+
+	// growing past the array limit switches to a bitmap container
+	b.Add(dense(0, 5000)...)
+	require.Equal(uint64(5001), b.Cardinality())
+	require.True(b.Contains(4999))
+	require.True(b.Contains(70000))
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Remove
+	b := roaring.New(1, 2, 70000)
+	b.Remove(2)
+	b.Remove(70000, 99)
+	require.Equal([]uint32{1}, b.ToSlice())
+	// testdoc end
+	b.Remove(1)
+	require.True(b.IsEmpty())
+
+	// shrinking below the array limit switches back to an array container
+	b.Add(dense(0, 5000)...)
+	b.Remove(dense(100, 4000)...)
+	require.Equal(uint64(1000), b.Cardinality())
+	require.Equal(append(dense(0, 100), dense(4100, 900)...), b.ToSlice())
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Contains
+	b := roaring.New(1, 1<<31)
+	require.True(b.Contains(1 << 31))
+	require.False(b.Contains(2))
+	// testdoc end
+}
+
+func (s *Suite) TestCardinality() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Cardinality
+	b := roaring.New(1, 2, 2, 1<<20)
+	require.Equal(uint64(3), b.Cardinality())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.IsEmpty
+	b := roaring.New()
+	require.True(b.IsEmpty())
+	b.Add(1)
+	require.False(b.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Equals
+	b1 := roaring.New(dense(0, 10000)...)
+	b2 := b1.Copy()
+	b2.RunOptimize()
+	require.True(b1.Equals(b2)) // representation does not matter
+	require.False(b1.Equals(roaring.New(1)))
+	// testdoc end
+	require.False(roaring.New(1, 2).Equals(roaring.New(1, 3)))
+	require.False(roaring.New(1).Equals(roaring.New(1 << 20)))
+}
+
+func (s *Suite) TestRank() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Rank
+	b := roaring.New(10, 20, 1<<20)
+	require.Equal(uint64(0), b.Rank(5))
+	require.Equal(uint64(1), b.Rank(10))
+	require.Equal(uint64(2), b.Rank(1000))
+	require.Equal(uint64(3), b.Rank(1<<30))
+	// testdoc end
+}
+
+func (s *Suite) TestSelect() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Select
+	b := roaring.New(10, 20, 1<<20)
+	v, ok := b.Select(2)
+	require.True(ok)
+	require.Equal(uint32(1<<20), v)
+	_, ok = b.Select(3)
+	require.False(ok)
+	// testdoc end
+}
+
+func (s *Suite) TestMin() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Min
+	v, ok := roaring.New(7, 3, 1<<20).Min()
+	require.True(ok)
+	require.Equal(uint32(3), v)
+	// testdoc end
+	_, ok = roaring.New().Min()
+	require.False(ok)
+}
+
+func (s *Suite) TestMax() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Max
+	v, ok := roaring.New(7, 3, 1<<20).Max()
+	require.True(ok)
+	require.Equal(uint32(1<<20), v)
+	// testdoc end
+	_, ok = roaring.New().Max()
+	require.False(ok)
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.All
+	b := roaring.New(1<<20, 2, 1)
+	var got []uint32
+	for v := range b.All() {
+		got = append(got, v)
+	}
+	require.Equal([]uint32{1, 2, 1 << 20}, got)
+	// testdoc end
+
+	got = nil
+	for v := range b.All() {
+		got = append(got, v)
+		break
+	}
+	require.Equal([]uint32{1}, got)
+}
+
+func (s *Suite) TestToSlice() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.ToSlice
+	b := roaring.New(9, 4, 6)
+	require.Equal([]uint32{4, 6, 9}, b.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Clear
+	b := roaring.New(1, 2)
+	b.Clear()
+	require.True(b.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Copy
+	b1 := roaring.New(1, 2)
+	b2 := b1.Copy()
+	b1.Add(3)
+	require.Equal([]uint32{1, 2}, b2.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestRunOptimize() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.RunOptimize
+	b := roaring.New(dense(0, 60000)...)
+	before, _ := b.MarshalBinary()
+	b.RunOptimize()
+	after, _ := b.MarshalBinary()
+	require.Less(len(after), len(before))
+	require.Equal(uint64(60000), b.Cardinality())
+	// testdoc end
+
+	// run containers answer queries and convert back on mutation
+	require.True(b.Contains(59999))
+	require.Equal(uint64(101), b.Rank(100))
+	v, ok := b.Select(500)
+	require.True(ok)
+	require.Equal(uint32(500), v)
+	b.Remove(100)
+	b.Add(70000)
+	require.Equal(uint64(60000), b.Cardinality())
+	require.False(b.Contains(100))
+
+	// sparse containers are left alone
+	sparse := roaring.New(1, 100, 1000)
+	sparse.RunOptimize()
+	require.Equal([]uint32{1, 100, 1000}, sparse.ToSlice())
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Union
+	b1 := roaring.New(1, 2, 3)
+	b2 := roaring.New(3, 4, 1<<20)
+	require.Equal([]uint32{1, 2, 3, 4, 1 << 20}, b1.Union(b2).ToSlice())
+	require.Equal([]uint32{1, 2, 3}, b1.ToSlice()) // b1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Intersection
+	b1 := roaring.New(1, 2, 3, 4)
+	b2 := roaring.New(3, 4, 5, 6)
+	require.Equal([]uint32{3, 4}, b1.Intersection(b2).ToSlice())
+	// testdoc end
+	require.True(b1.Intersection(roaring.New(1 << 20)).IsEmpty())
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.Difference
+	b1 := roaring.New(1, 2, 3, 4)
+	b2 := roaring.New(3, 4, 5, 6)
+	require.Equal([]uint32{1, 2}, b1.Difference(b2).ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestSymmetricDifference() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.SymmetricDifference
+	b1 := roaring.New(1, 2, 3, 4)
+	b2 := roaring.New(3, 4, 5, 6)
+	require.Equal([]uint32{1, 2, 5, 6}, b1.SymmetricDifference(b2).ToSlice())
+	// testdoc end
+}
+
+// randomValues mixes sparse, dense and run-shaped containers.
+func randomValues(r *rand.Rand) []uint32 {
+	var values []uint32
+	for range r.IntN(6) {
+		high := uint32(r.IntN(8)) << 16
+		switch r.IntN(3) {
+		case 0:
+			for range r.IntN(200) {
+				values = append(values, high|uint32(r.IntN(1<<16)))
+			}
+		case 1:
+			for range 5000 + r.IntN(10000) {
+				values = append(values, high|uint32(r.IntN(1<<16)))
+			}
+		default:
+			start := uint32(r.IntN(60000))
+			values = append(values, dense(high|start, r.IntN(5000))...)
+		}
+	}
+	return values
+}
+
+// TestRandomized checks set algebra, rank and select against set.Set.
+func (s *Suite) TestRandomized() {
+	require := require.New(s.T())
+
+	r := rand.New(rand.NewPCG(9, 10))
+	for i := range 40 {
+		va, vb := randomValues(r), randomValues(r)
+		sa, sb := set.New(va...), set.New(vb...)
+		a, b := roaring.New(va...), roaring.New(vb...)
+		if i%2 == 0 {
+			a.RunOptimize()
+		}
+
+		check := func(expected set.Set[uint32], actual *roaring.Bitmap) {
+			values := expected.ToSlice()
+			slices.Sort(values)
+			require.Equal(values, actual.ToSlice())
+		}
+		check(sa, a)
+		check(sa.Union(sb), a.Union(b))
+		check(sa.Intersection(sb), a.Intersection(b))
+		check(sa.Difference(sb), a.Difference(b))
+		check(sb.Difference(sa), b.Difference(a))
+		check(sa.SymmetricDifference(sb), a.SymmetricDifference(b))
+
+		sorted := a.ToSlice()
+		for range 20 {
+			if len(sorted) == 0 {
+				break
+			}
+			k := r.IntN(len(sorted))
+			v, ok := a.Select(uint64(k))
+			require.True(ok)
+			require.Equal(sorted[k], v)
+			require.Equal(uint64(k+1), a.Rank(sorted[k]))
+		}
+	}
+}
+
+func BenchmarkContains_Bitmap(b *testing.B) {
+	r := rand.New(rand.NewPCG(11, 12))
+	values := make([]uint32, 100000)
+	for i := range values {
+		values[i] = r.Uint32()
+	}
+	bm := roaring.New(values...)
+	for b.Loop() {
+		for _, v := range values[:1000] {
+			_ = bm.Contains(v)
+		}
+	}
+}
+
+func BenchmarkContains_Set(b *testing.B) {
+	r := rand.New(rand.NewPCG(11, 12))
+	values := make([]uint32, 100000)
+	for i := range values {
+		values[i] = r.Uint32()
+	}
+	st := set.New(values...)
+	for b.Loop() {
+		for _, v := range values[:1000] {
+			_ = st.Contains(v)
+		}
+	}
+}
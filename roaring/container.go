@@ -0,0 +1,448 @@
+package roaring
+
+import (
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+const (
+	// arrayMaxSize is the largest cardinality stored as an array container.
+	arrayMaxSize = 4096
+	// bitmapWords is the number of 64-bit words in a bitmap container.
+	bitmapWords = 1 << 16 / 64
+)
+
+// container holds the low 16 bits of the values sharing the same high 16 bits.
+// Mutating methods return the container to keep, which may have a different representation.
+type container interface {
+	add(x uint16) container
+	remove(x uint16) container
+	contains(x uint16) bool
+	cardinality() int
+	// rank returns the number of values less than or equal to x.
+	rank(x uint16) int
+	// selectAt returns the value at zero-based index i; i must be less than the cardinality.
+	selectAt(i int) uint16
+	all(yield func(uint16) bool) bool
+	clone() container
+	// toBitmap returns a new bitmap container holding the same values.
+	toBitmap() *bitmapContainer
+}
+
+// arrayContainer stores up to arrayMaxSize values as a sorted slice.
+type arrayContainer struct {
+	values []uint16
+}
+
+func (a *arrayContainer) add(x uint16) container {
+	i, found := slices.BinarySearch(a.values, x)
+	if found {
+		return a
+	}
+	if len(a.values) >= arrayMaxSize {
+		b := a.toBitmap()
+		b.add(x)
+		return b
+	}
+	a.values = slices.Insert(a.values, i, x)
+	return a
+}
+
+func (a *arrayContainer) remove(x uint16) container {
+	if i, found := slices.BinarySearch(a.values, x); found {
+		a.values = slices.Delete(a.values, i, i+1)
+	}
+	return a
+}
+
+func (a *arrayContainer) contains(x uint16) bool {
+	_, found := slices.BinarySearch(a.values, x)
+	return found
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.values)
+}
+
+func (a *arrayContainer) rank(x uint16) int {
+	i, found := slices.BinarySearch(a.values, x)
+	if found {
+		return i + 1
+	}
+	return i
+}
+
+func (a *arrayContainer) selectAt(i int) uint16 {
+	return a.values[i]
+}
+
+func (a *arrayContainer) all(yield func(uint16) bool) bool {
+	for _, v := range a.values {
+		if !yield(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *arrayContainer) clone() container {
+	return &arrayContainer{values: slices.Clone(a.values)}
+}
+
+func (a *arrayContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	for _, v := range a.values {
+		b.words[v/64] |= 1 << (v % 64)
+	}
+	b.card = len(a.values)
+	return b
+}
+
+// bitmapContainer stores values as a fixed 2^16-bit vector.
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	card  int
+}
+
+func (b *bitmapContainer) add(x uint16) container {
+	mask := uint64(1) << (x % 64)
+	if b.words[x/64]&mask == 0 {
+		b.words[x/64] |= mask
+		b.card++
+	}
+	return b
+}
+
+func (b *bitmapContainer) remove(x uint16) container {
+	mask := uint64(1) << (x % 64)
+	if b.words[x/64]&mask != 0 {
+		b.words[x/64] &^= mask
+		b.card--
+	}
+	return b.normalize()
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) rank(x uint16) int {
+	n := 0
+	for _, w := range b.words[:x/64] {
+		n += bits.OnesCount64(w)
+	}
+	// 2<<63 wraps to zero, so the mask covers the whole word for x%64 == 63.
+	mask := uint64(2)<<(x%64) - 1
+	return n + bits.OnesCount64(b.words[x/64]&mask)
+}
+
+func (b *bitmapContainer) selectAt(i int) uint16 {
+	for wi, w := range b.words {
+		n := bits.OnesCount64(w)
+		if i >= n {
+			i -= n
+			continue
+		}
+		for ; i > 0; i-- {
+			w &= w - 1
+		}
+		return uint16(wi*64 + bits.TrailingZeros64(w))
+	}
+	panic("roaring: select out of range")
+}
+
+func (b *bitmapContainer) all(yield func(uint16) bool) bool {
+	for wi, w := range b.words {
+		for w != 0 {
+			if !yield(uint16(wi*64 + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+func (b *bitmapContainer) clone() container {
+	c := *b
+	return &c
+}
+
+func (b *bitmapContainer) toBitmap() *bitmapContainer {
+	c := *b
+	return &c
+}
+
+func (b *bitmapContainer) recount() {
+	b.card = 0
+	for _, w := range b.words {
+		b.card += bits.OnesCount64(w)
+	}
+}
+
+// normalize converts the bitmap to an array container when it is small enough.
+func (b *bitmapContainer) normalize() container {
+	if b.card > arrayMaxSize {
+		return b
+	}
+	a := &arrayContainer{values: make([]uint16, 0, b.card)}
+	b.all(func(v uint16) bool {
+		a.values = append(a.values, v)
+		return true
+	})
+	return a
+}
+
+// interval is a run of consecutive values from start to start+length inclusive,
+// matching the on-disk layout of the Roaring format.
+type interval struct {
+	start  uint16
+	length uint16
+}
+
+func (iv interval) last() int {
+	return int(iv.start) + int(iv.length)
+}
+
+// runContainer stores values as sorted, non-overlapping, non-adjacent runs.
+// It is only produced by RunOptimize and deserialization; mutation converts it
+// back to an array or bitmap container.
+type runContainer struct {
+	runs []interval
+}
+
+func (r *runContainer) add(x uint16) container {
+	if r.contains(x) {
+		return r
+	}
+	return r.expand().add(x)
+}
+
+func (r *runContainer) remove(x uint16) container {
+	if !r.contains(x) {
+		return r
+	}
+	return r.expand().remove(x)
+}
+
+// expand converts the runs to an array or bitmap container depending on the cardinality.
+func (r *runContainer) expand() container {
+	return r.toBitmap().normalize()
+}
+
+func (r *runContainer) contains(x uint16) bool {
+	// index of the first run starting after x
+	i := sort.Search(len(r.runs), func(i int) bool { return r.runs[i].start > x })
+	return i > 0 && int(x) <= r.runs[i-1].last()
+}
+
+func (r *runContainer) cardinality() int {
+	n := 0
+	for _, iv := range r.runs {
+		n += int(iv.length) + 1
+	}
+	return n
+}
+
+func (r *runContainer) rank(x uint16) int {
+	n := 0
+	for _, iv := range r.runs {
+		if iv.start > x {
+			break
+		}
+		n += min(int(x), iv.last()) - int(iv.start) + 1
+	}
+	return n
+}
+
+func (r *runContainer) selectAt(i int) uint16 {
+	for _, iv := range r.runs {
+		if size := int(iv.length) + 1; i >= size {
+			i -= size
+			continue
+		}
+		return iv.start + uint16(i)
+	}
+	panic("roaring: select out of range")
+}
+
+func (r *runContainer) all(yield func(uint16) bool) bool {
+	for _, iv := range r.runs {
+		for v := int(iv.start); v <= iv.last(); v++ {
+			if !yield(uint16(v)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *runContainer) clone() container {
+	return &runContainer{runs: slices.Clone(r.runs)}
+}
+
+func (r *runContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	for _, iv := range r.runs {
+		for v := int(iv.start); v <= iv.last(); v++ {
+			b.words[v/64] |= 1 << (v % 64)
+		}
+	}
+	b.card = r.cardinality()
+	return b
+}
+
+// runsOf computes the runs of consecutive values in c.
+func runsOf(c container) []interval {
+	var runs []interval
+	c.all(func(v uint16) bool {
+		if n := len(runs); n > 0 && runs[n-1].last()+1 == int(v) {
+			runs[n-1].length++
+		} else {
+			runs = append(runs, interval{start: v})
+		}
+		return true
+	})
+	return runs
+}
+
+// optimize returns the smallest serialized representation of c.
+func optimize(c container) container {
+	card := c.cardinality()
+	runs := runsOf(c)
+	runSize := 2 + 4*len(runs)
+	plainSize := bitmapWords * 8
+	if card <= arrayMaxSize {
+		plainSize = 2 * card
+	}
+	if runSize < plainSize {
+		return &runContainer{runs: runs}
+	}
+	if r, ok := c.(*runContainer); ok {
+		return r.expand()
+	}
+	return c
+}
+
+func union(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		if y, ok := b.(*arrayContainer); ok && len(x.values)+len(y.values) <= arrayMaxSize {
+			return &arrayContainer{values: mergeArrays(x.values, y.values, true, true, true)}
+		}
+	}
+	result := a.toBitmap()
+	other := b.toBitmap()
+	for i := range result.words {
+		result.words[i] |= other.words[i]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+func intersection(a, b container) container {
+	if _, ok := b.(*arrayContainer); ok {
+		a, b = b, a
+	}
+	if x, ok := a.(*arrayContainer); ok {
+		values := make([]uint16, 0, len(x.values))
+		for _, v := range x.values {
+			if b.contains(v) {
+				values = append(values, v)
+			}
+		}
+		return &arrayContainer{values: values}
+	}
+	result := a.toBitmap()
+	other := b.toBitmap()
+	for i := range result.words {
+		result.words[i] &= other.words[i]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+func difference(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		values := make([]uint16, 0, len(x.values))
+		for _, v := range x.values {
+			if !b.contains(v) {
+				values = append(values, v)
+			}
+		}
+		return &arrayContainer{values: values}
+	}
+	result := a.toBitmap()
+	other := b.toBitmap()
+	for i := range result.words {
+		result.words[i] &^= other.words[i]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+func symmetricDifference(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		if y, ok := b.(*arrayContainer); ok && len(x.values)+len(y.values) <= arrayMaxSize {
+			return &arrayContainer{values: mergeArrays(x.values, y.values, true, false, true)}
+		}
+	}
+	result := a.toBitmap()
+	other := b.toBitmap()
+	for i := range result.words {
+		result.words[i] ^= other.words[i]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+// mergeArrays merges two sorted arrays, keeping the values found only in a,
+// in both, and only in b, as selected by the flags.
+func mergeArrays(a, b []uint16, onlyA, both, onlyB bool) []uint16 {
+	result := make([]uint16, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			if onlyA {
+				result = append(result, a[i])
+			}
+			i++
+		case a[i] > b[j]:
+			if onlyB {
+				result = append(result, b[j])
+			}
+			j++
+		default:
+			if both {
+				result = append(result, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if onlyA {
+		result = append(result, a[i:]...)
+	}
+	if onlyB {
+		result = append(result, b[j:]...)
+	}
+	return result
+}
+
+// equalContainers checks if two containers hold the same values regardless of representation.
+func equalContainers(a, b container) bool {
+	if a.cardinality() != b.cardinality() {
+		return false
+	}
+	x, xok := a.(*arrayContainer)
+	y, yok := b.(*arrayContainer)
+	if xok && yok {
+		return slices.Equal(x.values, y.values)
+	}
+	return a.toBitmap().words == b.toBitmap().words
+}
@@ -0,0 +1,329 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Cookies and thresholds defined by the portable Roaring format specification.
+const (
+	serialCookieNoRunContainer = 12346
+	serialCookie               = 12347
+	noOffsetThreshold          = 4
+)
+
+var (
+	_ io.WriterTo                = (*Bitmap)(nil)
+	_ io.ReaderFrom              = (*Bitmap)(nil)
+	_ encoding.BinaryMarshaler   = (*Bitmap)(nil)
+	_ encoding.BinaryUnmarshaler = (*Bitmap)(nil)
+)
+
+// ErrInvalidFormat is returned when decoding data that is not a valid portable Roaring bitmap.
+var ErrInvalidFormat = errors.New("roaring: invalid format")
+
+// WriteTo writes the bitmap to w in the portable Roaring format.
+// Call RunOptimize beforehand to store runs of consecutive values compactly.
+//
+// Example:
+//
+//	b := roaring.New(1, 2, 3, 1000, 70000)
+//	var buf bytes.Buffer
+//	n, err := b.WriteTo(&buf)
+//	require.NoError(err)
+//	require.Equal(int64(buf.Len()), n)
+func (b *Bitmap) WriteTo(w io.Writer) (int64, error) {
+	data, err := b.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// MarshalBinary encodes the bitmap in the portable Roaring format.
+//
+// Example:
+//
+//	b := roaring.New(5, 1<<16)
+//	for v := uint32(10); v < 20; v++ {
+//		b.Add(v)
+//	}
+//	for v := uint32(3 << 16); v < 3<<16+100; v++ {
+//		b.Add(v)
+//	}
+//	b.RunOptimize()
+//	data, err := b.MarshalBinary()
+//	require.NoError(err)
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	n := len(b.containers)
+	hasRun := false
+	for _, c := range b.containers {
+		if _, ok := c.(*runContainer); ok {
+			hasRun = true
+			break
+		}
+	}
+
+	var data []byte
+	if hasRun {
+		data = binary.LittleEndian.AppendUint32(data, uint32(serialCookie|(n-1)<<16))
+		runFlags := make([]byte, (n+7)/8)
+		for i, c := range b.containers {
+			if _, ok := c.(*runContainer); ok {
+				runFlags[i/8] |= 1 << (i % 8)
+			}
+		}
+		data = append(data, runFlags...)
+	} else {
+		data = binary.LittleEndian.AppendUint32(data, serialCookieNoRunContainer)
+		data = binary.LittleEndian.AppendUint32(data, uint32(n))
+	}
+
+	for i, c := range b.containers {
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		data = binary.LittleEndian.AppendUint16(data, uint16(c.cardinality()-1))
+	}
+
+	if !hasRun || n >= noOffsetThreshold {
+		offset := len(data) + 4*n
+		for _, c := range b.containers {
+			data = binary.LittleEndian.AppendUint32(data, uint32(offset))
+			offset += serializedSize(c)
+		}
+	}
+
+	for _, c := range b.containers {
+		data = appendContainer(data, c)
+	}
+	return data, nil
+}
+
+// serializedSize returns the number of bytes appendContainer writes for c.
+func serializedSize(c container) int {
+	if r, ok := c.(*runContainer); ok {
+		return 2 + 4*len(r.runs)
+	}
+	if card := c.cardinality(); card <= arrayMaxSize {
+		return 2 * card
+	}
+	return bitmapWords * 8
+}
+
+// appendContainer encodes c. Non-run containers are written as arrays or bitmaps according
+// to their cardinality, since readers infer the container type from the cardinality alone.
+func appendContainer(data []byte, c container) []byte {
+	if r, ok := c.(*runContainer); ok {
+		data = binary.LittleEndian.AppendUint16(data, uint16(len(r.runs)))
+		for _, iv := range r.runs {
+			data = binary.LittleEndian.AppendUint16(data, iv.start)
+			data = binary.LittleEndian.AppendUint16(data, iv.length)
+		}
+		return data
+	}
+	if c.cardinality() <= arrayMaxSize {
+		c.all(func(v uint16) bool {
+			data = binary.LittleEndian.AppendUint16(data, v)
+			return true
+		})
+		return data
+	}
+	for _, w := range c.toBitmap().words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data
+}
+
+// ReadFrom reads a bitmap in the portable Roaring format from r, replacing the contents of b.
+// It reads exactly the bytes of one serialized bitmap.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	_, err := roaring.New(1, 2, 3).WriteTo(&buf)
+//	require.NoError(err)
+//
+//	b := roaring.New()
+//	_, err = b.ReadFrom(&buf)
+//	require.NoError(err)
+//	require.Equal([]uint32{1, 2, 3}, b.ToSlice())
+func (b *Bitmap) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	decoded, err := decode(cr)
+	if err != nil {
+		return cr.n, err
+	}
+	*b = *decoded
+	return cr.n, nil
+}
+
+// UnmarshalBinary decodes a bitmap in the portable Roaring format, replacing the contents of b.
+//
+// Example:
+//
+//	data, err := roaring.New(1, 2, 3, 1000, 70000).MarshalBinary()
+//	require.NoError(err)
+//
+//	b := roaring.New()
+//	err = b.UnmarshalBinary(data)
+//	require.NoError(err)
+//	require.Equal([]uint32{1, 2, 3, 1000, 70000}, b.ToSlice())
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	decoded, err := decode(r)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidFormat, r.Len())
+	}
+	*b = *decoded
+	return nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// decoder reads little-endian values and remembers the first error.
+type decoder struct {
+	r   io.Reader
+	buf [8]byte
+	err error
+}
+
+func (d *decoder) read(n int) []byte {
+	if d.err != nil {
+		return d.buf[:n]
+	}
+	if _, err := io.ReadFull(d.r, d.buf[:n]); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		d.err = fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	return d.buf[:n]
+}
+
+func (d *decoder) uint16() uint16 { return binary.LittleEndian.Uint16(d.read(2)) }
+func (d *decoder) uint32() uint32 { return binary.LittleEndian.Uint32(d.read(4)) }
+func (d *decoder) uint64() uint64 { return binary.LittleEndian.Uint64(d.read(8)) }
+
+func decode(r io.Reader) (*Bitmap, error) {
+	d := &decoder{r: r}
+
+	cookie := d.uint32()
+	if d.err != nil {
+		return nil, d.err
+	}
+	var n int
+	var runFlags []byte
+	switch {
+	case cookie == serialCookieNoRunContainer:
+		n = int(d.uint32())
+	case cookie&0xFFFF == serialCookie:
+		n = int(cookie>>16) + 1
+		runFlags = make([]byte, (n+7)/8)
+		if _, err := io.ReadFull(r, runFlags); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, io.ErrUnexpectedEOF)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown cookie %d", ErrInvalidFormat, cookie)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if n > 1<<16 {
+		return nil, fmt.Errorf("%w: %d containers", ErrInvalidFormat, n)
+	}
+
+	isRun := func(i int) bool {
+		return runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0
+	}
+
+	b := &Bitmap{
+		keys:       make([]uint16, n),
+		containers: make([]container, n),
+	}
+	cards := make([]int, n)
+	for i := range n {
+		b.keys[i] = d.uint16()
+		cards[i] = int(d.uint16()) + 1
+		if i > 0 && b.keys[i] <= b.keys[i-1] {
+			return nil, fmt.Errorf("%w: keys are not strictly increasing", ErrInvalidFormat)
+		}
+	}
+	if runFlags == nil || n >= noOffsetThreshold {
+		// Containers are stored back to back, so the offsets are not needed for sequential reading.
+		for range n {
+			d.uint32()
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	for i := range n {
+		c, err := decodeContainer(d, isRun(i), cards[i])
+		if err != nil {
+			return nil, err
+		}
+		b.containers[i] = c
+	}
+	return b, nil
+}
+
+func decodeContainer(d *decoder, isRun bool, card int) (container, error) {
+	switch {
+	case isRun:
+		r := &runContainer{runs: make([]interval, d.uint16())}
+		next := 0
+		for i := range r.runs {
+			iv := interval{start: d.uint16(), length: d.uint16()}
+			if d.err != nil {
+				return nil, d.err
+			}
+			if int(iv.start) < next || iv.last() > 0xFFFF {
+				return nil, fmt.Errorf("%w: invalid run", ErrInvalidFormat)
+			}
+			r.runs[i] = iv
+			next = iv.last() + 1
+		}
+		if d.err == nil && r.cardinality() != card {
+			return nil, fmt.Errorf("%w: run cardinality mismatch", ErrInvalidFormat)
+		}
+		return r, d.err
+	case card <= arrayMaxSize:
+		a := &arrayContainer{values: make([]uint16, card)}
+		for i := range a.values {
+			a.values[i] = d.uint16()
+			if d.err == nil && i > 0 && a.values[i] <= a.values[i-1] {
+				return nil, fmt.Errorf("%w: array values are not strictly increasing", ErrInvalidFormat)
+			}
+		}
+		return a, d.err
+	default:
+		bc := &bitmapContainer{}
+		for i := range bc.words {
+			bc.words[i] = d.uint64()
+		}
+		bc.recount()
+		if d.err == nil && bc.card != card {
+			return nil, fmt.Errorf("%w: bitmap cardinality mismatch", ErrInvalidFormat)
+		}
+		return bc, d.err
+	}
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,164 @@
+package roaring_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/roaring"
+)
+
+type SerializationSuite struct {
+	suite.Suite
+}
+
+func TestSerializationSuite(t *testing.T) {
+	suite.Run(t, new(SerializationSuite))
+}
+
+// Reference encodings produced by github.com/RoaringBitmap/roaring.
+const (
+	// {1, 2, 3, 1000, 70000}: two array containers, no runs.
+	goldenNoRuns = "3a3000000200000000000300010000001800000020000000010002000300e8037011"
+	// {5, 10..19, 65536, 196608..196707} after RunOptimize: containers 0 and 2 are runs.
+	goldenRuns = "3b3002000500000a0001000000030063000200050000000a0009000000010000006300"
+)
+
+func decodeHex(s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func (s *SerializationSuite) TestWriteTo() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.WriteTo
+	b := roaring.New(1, 2, 3, 1000, 70000)
+	var buf bytes.Buffer
+	n, err := b.WriteTo(&buf)
+	require.NoError(err)
+	require.Equal(int64(buf.Len()), n)
+	// testdoc end
+	require.Equal(goldenNoRuns, hex.EncodeToString(buf.Bytes()))
+}
+
+func (s *SerializationSuite) TestReadFrom() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.ReadFrom
+	var buf bytes.Buffer
+	_, err := roaring.New(1, 2, 3).WriteTo(&buf)
+	require.NoError(err)
+
+	b := roaring.New()
+	_, err = b.ReadFrom(&buf)
+	require.NoError(err)
+	require.Equal([]uint32{1, 2, 3}, b.ToSlice())
+	// testdoc end
+
+	// ReadFrom consumes exactly one bitmap
+	buf.Reset()
+	_, _ = roaring.New(1).WriteTo(&buf)
+	_, _ = roaring.New(2).WriteTo(&buf)
+	first, second := roaring.New(), roaring.New()
+	n, err := first.ReadFrom(&buf)
+	require.NoError(err)
+	require.Positive(n)
+	_, err = second.ReadFrom(&buf)
+	require.NoError(err)
+	require.Equal([]uint32{1}, first.ToSlice())
+	require.Equal([]uint32{2}, second.ToSlice())
+
+	_, err = roaring.New().ReadFrom(bytes.NewReader(nil))
+	require.ErrorIs(err, roaring.ErrInvalidFormat)
+}
+
+func (s *SerializationSuite) TestMarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.MarshalBinary
+	b := roaring.New(5, 1<<16)
+	for v := uint32(10); v < 20; v++ {
+		b.Add(v)
+	}
+	for v := uint32(3 << 16); v < 3<<16+100; v++ {
+		b.Add(v)
+	}
+	b.RunOptimize()
+	data, err := b.MarshalBinary()
+	require.NoError(err)
+	// testdoc end
+	require.Equal(goldenRuns, hex.EncodeToString(data))
+
+	data, err = roaring.New().MarshalBinary()
+	require.NoError(err)
+	decoded := roaring.New(1)
+	require.NoError(decoded.UnmarshalBinary(data))
+	require.True(decoded.IsEmpty())
+}
+
+func (s *SerializationSuite) TestUnmarshalBinary() {
+	require := require.New(s.T())
+	// testdoc begin Bitmap.UnmarshalBinary
+	data, err := roaring.New(1, 2, 3, 1000, 70000).MarshalBinary()
+	require.NoError(err)
+
+	b := roaring.New()
+	err = b.UnmarshalBinary(data)
+	require.NoError(err)
+	require.Equal([]uint32{1, 2, 3, 1000, 70000}, b.ToSlice())
+	// testdoc end
+
+	require.NoError(b.UnmarshalBinary(decodeHex(goldenRuns)))
+	require.Equal(uint64(112), b.Cardinality())
+	require.True(b.Contains(15))
+	require.True(b.Contains(3<<16 + 99))
+	require.False(b.Contains(20))
+}
+
+func (s *SerializationSuite) TestRoundTrip() {
+	require := require.New(s.T())
+
+	for _, optimize := range []bool{false, true} {
+		b := roaring.New(dense(0, 10000)...) // bitmap container
+		b.Add(dense(1<<20, 3)...)            // array container
+		b.Add(dense(5<<16, 65536)...)        // full container
+		b.Add(1, 1<<31, 1<<32-1)
+		if optimize {
+			b.RunOptimize()
+		}
+		data, err := b.MarshalBinary()
+		require.NoError(err)
+
+		decoded := roaring.New()
+		require.NoError(decoded.UnmarshalBinary(data))
+		require.True(decoded.Equals(b))
+
+		again, err := decoded.MarshalBinary()
+		require.NoError(err)
+		require.Equal(data, again)
+	}
+}
+
+func (s *SerializationSuite) TestInvalid() {
+	require := require.New(s.T())
+
+	valid := decodeHex(goldenNoRuns)
+	for name, data := range map[string][]byte{
+		"empty":         {},
+		"bad cookie":    {1, 2, 3, 4},
+		"truncated":     valid[:len(valid)-1],
+		"trailing":      append(append([]byte{}, valid...), 0),
+		"unsorted keys": decodeHex("3a30000002000000010000000000000018000000190000000100e803"),
+	} {
+		err := roaring.New().UnmarshalBinary(data)
+		require.ErrorIs(err, roaring.ErrInvalidFormat, name)
+	}
+
+	_, err := roaring.New().ReadFrom(io.LimitReader(bytes.NewReader(valid), 10))
+	require.ErrorIs(err, roaring.ErrInvalidFormat)
+}
@@ -0,0 +1,220 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// MaxPowerSetLen is the largest set that PowerSet accepts.
+// A set of this size already has more than a billion subsets.
+const MaxPowerSetLen = 30
+
+// MaxGeneratedLen is the largest number of results Combinations and Permutations accept,
+// the same as the number of subsets of a set of MaxPowerSetLen elements.
+const MaxGeneratedLen = 1 << MaxPowerSetLen
+
+// PowerSet returns an iterator over all subsets of s, from the empty set up to s itself.
+// Subsets are yielded in order of size, and subsets of the same size are yielded
+// in lexicographic order when the element type is ordered.
+// Every yielded set is newly allocated and may be kept by the caller.
+// It panics if s has more than MaxPowerSetLen elements.
+//
+// Example:
+//
+//	var subsets [][]int
+//	for sub := range set.PowerSet(set.New(3, 1, 2)) {
+//		subsets = append(subsets, slices.Sorted(sub.All()))
+//	}
+//	require.Equal([][]int{nil, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}, subsets)
+func PowerSet[T comparable](s Set[T]) iter.Seq[Set[T]] {
+	if n := s.Len(); n > MaxPowerSetLen {
+		panic(fmt.Sprintf("set: power set of %d elements exceeds MaxPowerSetLen (%d)", n, MaxPowerSetLen))
+	}
+	return func(yield func(Set[T]) bool) {
+		elements := s.orderedSlice()
+		for k := 0; k <= len(elements); k++ {
+			for c := range combinations(elements, k) {
+				if !yield(c) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Combinations returns an iterator over all subsets of s with exactly k elements.
+// Subsets are yielded in lexicographic order when the element type is ordered.
+// Nothing is yielded if k is negative or greater than s.Len().
+// It panics if there are more than MaxGeneratedLen subsets of k elements.
+//
+// Example:
+//
+//	var pairs [][]string
+//	for c := range set.Combinations(set.New("c", "a", "b", "d"), 2) {
+//		pairs = append(pairs, slices.Sorted(c.All()))
+//	}
+//	require.Equal([][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}}, pairs)
+func Combinations[T comparable](s Set[T], k int) iter.Seq[Set[T]] {
+	if count := combinationCount(s.Len(), k); count > MaxGeneratedLen {
+		panic(fmt.Sprintf("set: combinations of %d out of %d elements exceed MaxGeneratedLen (%d)", k, s.Len(), MaxGeneratedLen))
+	}
+	return func(yield func(Set[T]) bool) {
+		for c := range combinations(s.orderedSlice(), k) {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// Permutations returns an iterator over all ordered arrangements of k distinct elements of s.
+// Arrangements are yielded in lexicographic order when the element type is ordered.
+// Every yielded slice is newly allocated and may be kept by the caller.
+// Nothing is yielded if k is negative or greater than s.Len().
+// It panics if there are more than MaxGeneratedLen arrangements of k elements.
+//
+// Example:
+//
+//	perms := slices.Collect(set.Permutations(set.New(3, 1, 2), 2))
+//	require.Equal([][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}, perms)
+func Permutations[T comparable](s Set[T], k int) iter.Seq[[]T] {
+	if count := permutationCount(s.Len(), k); count > MaxGeneratedLen {
+		panic(fmt.Sprintf("set: permutations of %d out of %d elements exceed MaxGeneratedLen (%d)", k, s.Len(), MaxGeneratedLen))
+	}
+	return func(yield func([]T) bool) {
+		elements := s.orderedSlice()
+		if k < 0 || k > len(elements) {
+			return
+		}
+		used := make([]bool, len(elements))
+		current := make([]T, 0, k)
+
+		var walk func() bool
+		walk = func() bool {
+			if len(current) == k {
+				return yield(slices.Clone(current))
+			}
+			for i, e := range elements {
+				if used[i] {
+					continue
+				}
+				used[i] = true
+				current = append(current, e)
+				ok := walk()
+				current = current[:len(current)-1]
+				used[i] = false
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		walk()
+	}
+}
+
+// Product returns an iterator over the cartesian product of a and b.
+// Pairs are ordered by the element of a, then by the element of b,
+// when the element types are ordered.
+//
+// Example:
+//
+//	type pair struct {
+//		N int
+//		S string
+//	}
+//	var pairs []pair
+//	for n, str := range set.Product(set.New(2, 1), set.New("b", "a")) {
+//		pairs = append(pairs, pair{n, str})
+//	}
+//	require.Equal([]pair{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}, pairs)
+func Product[A, B comparable](a Set[A], b Set[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		left, right := a.orderedSlice(), b.orderedSlice()
+		for _, x := range left {
+			for _, y := range right {
+				if !yield(x, y) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// combinationCount returns the number of k-element subsets of n elements,
+// or a value above MaxGeneratedLen once the count exceeds it.
+func combinationCount(n, k int) uint64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	k = min(k, n-k)
+	count := uint64(1)
+	for i := range k {
+		// count is C(n, i), so count*(n-i) is divisible by i+1
+		hi, lo := bits.Mul64(count, uint64(n-i))
+		if hi != 0 {
+			return MaxGeneratedLen + 1
+		}
+		count = lo / uint64(i+1)
+		if count > MaxGeneratedLen {
+			return count
+		}
+	}
+	return count
+}
+
+// permutationCount returns the number of ordered arrangements of k out of n elements,
+// or a value above MaxGeneratedLen once the count exceeds it.
+func permutationCount(n, k int) uint64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	count := uint64(1)
+	for i := range k {
+		count *= uint64(n - i)
+		if count > MaxGeneratedLen {
+			return count
+		}
+	}
+	return count
+}
+
+// combinations yields the k-element subsets of elements in lexicographic order of their indices.
+func combinations[T comparable](elements []T, k int) iter.Seq[Set[T]] {
+	return func(yield func(Set[T]) bool) {
+		n := len(elements)
+		if k < 0 || k > n {
+			return
+		}
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			c := Set[T]{data: make(map[T]exists, k)}
+			for _, i := range indices {
+				c.data[elements[i]] = exists{}
+			}
+			if !yield(c) {
+				return
+			}
+
+			// Advance the rightmost index that still has room, then reset the ones after it.
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,166 @@
+package set_test
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type CombinatoricsSuite struct {
+	suite.Suite
+}
+
+func TestCombinatoricsSuite(t *testing.T) {
+	suite.Run(t, new(CombinatoricsSuite))
+}
+
+func sortedSlices(seq iter.Seq[set.Set[int]]) [][]int {
+	var result [][]int
+	for s := range seq {
+		e := s.ToSlice()
+		slices.Sort(e)
+		result = append(result, e)
+	}
+	return result
+}
+
+func (s *CombinatoricsSuite) TestPowerSet() {
+	require := require.New(s.T())
+	// testdoc begin PowerSet
+	var subsets [][]int
+	for sub := range set.PowerSet(set.New(3, 1, 2)) {
+		subsets = append(subsets, slices.Sorted(sub.All()))
+	}
+	require.Equal([][]int{nil, {1}, {2}, {3}, {1, 2}, {1, 3}, {2, 3}, {1, 2, 3}}, subsets)
+	// testdoc end
+
+	require.Equal([][]int{{}}, sortedSlices(set.PowerSet(set.New[int]())))
+
+	// yielded sets are independent of each other
+	all := slices.Collect(set.PowerSet(set.New(1, 2)))
+	all[0].Insert(100)
+	require.True(all[1].Equals(set.New(1)))
+
+	// early termination
+	n := 0
+	for range set.PowerSet(set.New(1, 2, 3, 4)) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	require.Equal(3, n)
+
+	large := set.New[int]()
+	for i := range set.MaxPowerSetLen + 1 {
+		large.Insert(i)
+	}
+	require.Panics(func() { set.PowerSet(large) })
+	large.Remove(0)
+	require.NotPanics(func() { set.PowerSet(large) })
+}
+
+func (s *CombinatoricsSuite) TestCombinations() {
+	require := require.New(s.T())
+	// testdoc begin Combinations
+	var pairs [][]string
+	for c := range set.Combinations(set.New("c", "a", "b", "d"), 2) {
+		pairs = append(pairs, slices.Sorted(c.All()))
+	}
+	require.Equal([][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}}, pairs)
+	// testdoc end
+
+	st := set.New(1, 2, 3)
+	require.Equal([][]int{{}}, sortedSlices(set.Combinations(st, 0)))
+	require.Equal([][]int{{1, 2, 3}}, sortedSlices(set.Combinations(st, 3)))
+	require.Empty(sortedSlices(set.Combinations(st, 4)))
+	require.Empty(sortedSlices(set.Combinations(st, -1)))
+
+	// C(10, 4) = 210 distinct subsets
+	big := set.New(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	seen := set.New[[4]int]()
+	for c := range set.Combinations(big, 4) {
+		e := slices.Sorted(c.All())
+		seen.Insert([4]int(e))
+	}
+	require.Equal(210, seen.Len())
+
+	// C(34, 17) exceeds MaxGeneratedLen, C(32, 16) does not
+	large := set.New[int]()
+	for i := range 34 {
+		large.Insert(i)
+	}
+	require.Panics(func() { set.Combinations(large, 17) })
+	require.Panics(func() { set.Combinations(large, 16) })
+	require.NotPanics(func() { set.Combinations(large, 3) })
+	require.NotPanics(func() { set.Combinations(large, 40) })
+	large.Remove(0, 1)
+	require.NotPanics(func() { set.Combinations(large, 16) })
+}
+
+func (s *CombinatoricsSuite) TestPermutations() {
+	require := require.New(s.T())
+	// testdoc begin Permutations
+	perms := slices.Collect(set.Permutations(set.New(3, 1, 2), 2))
+	require.Equal([][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}, perms)
+	// testdoc end
+
+	st := set.New(1, 2, 3, 4)
+	require.Len(slices.Collect(set.Permutations(st, 4)), 24)
+	require.Equal([][]int{{}}, slices.Collect(set.Permutations(st, 0)))
+	require.Empty(slices.Collect(set.Permutations(st, 5)))
+	require.Empty(slices.Collect(set.Permutations(st, -1)))
+
+	n := 0
+	for range set.Permutations(st, 4) {
+		n++
+		if n == 5 {
+			break
+		}
+	}
+	require.Equal(5, n)
+
+	// 13!/3! fits in MaxGeneratedLen, 13!/2! does not
+	large := set.New[int]()
+	for i := range 13 {
+		large.Insert(i)
+	}
+	require.NotPanics(func() { set.Permutations(large, 10) })
+	require.Panics(func() { set.Permutations(large, 11) })
+	require.Panics(func() { set.Permutations(large, 13) })
+	require.NotPanics(func() { set.Permutations(large, 14) })
+}
+
+func (s *CombinatoricsSuite) TestProduct() {
+	require := require.New(s.T())
+	// testdoc begin Product
+	type pair struct {
+		N int
+		S string
+	}
+	var pairs []pair
+	for n, str := range set.Product(set.New(2, 1), set.New("b", "a")) {
+		pairs = append(pairs, pair{n, str})
+	}
+	require.Equal([]pair{{1, "a"}, {1, "b"}, {2, "a"}, {2, "b"}}, pairs)
+	// testdoc end
+
+	n := 0
+	for range set.Product(set.New(1, 2), set.New[string]()) {
+		n++
+	}
+	require.Equal(0, n)
+
+	for range set.Product(set.New(1, 2, 3), set.New(1, 2, 3)) {
+		n++
+		if n == 4 {
+			break
+		}
+	}
+	require.Equal(4, n)
+}
@@ -0,0 +1,281 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	_ json.Marshaler           = Set[int]{}
+	_ json.Unmarshaler         = (*Set[int])(nil)
+	_ encoding.TextMarshaler   = Set[string]{}
+	_ encoding.TextUnmarshaler = (*Set[string])(nil)
+	_ gob.GobEncoder           = Set[int]{}
+	_ gob.GobDecoder           = (*Set[int])(nil)
+)
+
+// ErrDuplicateElement is returned by UnmarshalJSON when duplicate detection is enabled
+// and the input contains the same element more than once.
+var ErrDuplicateElement = errors.New("set: duplicate element")
+
+// ErrNotStringLike is returned by the text encoding methods when the element type
+// does not have string as its underlying type.
+var ErrNotStringLike = errors.New("set: element type is not string-like")
+
+// DecodeOption configures the behavior of UnmarshalJSON.
+type DecodeOption func(*decodeConfig)
+
+type decodeConfig struct {
+	disallowDuplicates bool
+}
+
+// DisallowDuplicates makes UnmarshalJSON fail with ErrDuplicateElement
+// when the input array contains the same element more than once.
+//
+// Example:
+//
+//	_, err := set.UnmarshalJSON[string]([]byte(`["a", "a"]`), set.DisallowDuplicates())
+//	require.ErrorIs(err, set.ErrDuplicateElement)
+func DisallowDuplicates() DecodeOption {
+	return func(c *decodeConfig) {
+		c.disallowDuplicates = true
+	}
+}
+
+// UnmarshalJSON decodes a JSON array into a new Set, applying the given options.
+//
+// Example:
+//
+//	st, err := set.UnmarshalJSON[int]([]byte(`[1, 2, 3]`))
+//	require.NoError(err)
+//	require.True(st.Equals(set.New(1, 2, 3)))
+//
+//	_, err = set.UnmarshalJSON[int]([]byte(`[1, 2, 1]`), set.DisallowDuplicates())
+//	require.ErrorIs(err, set.ErrDuplicateElement)
+func UnmarshalJSON[T comparable](data []byte, opts ...DecodeOption) (Set[T], error) {
+	var config decodeConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return Set[T]{}, err
+	}
+	s := New[T]()
+	for _, e := range elements {
+		if config.disallowDuplicates && s.Contains(e) {
+			return Set[T]{}, fmt.Errorf("%w: %v", ErrDuplicateElement, e)
+		}
+		s.Insert(e)
+	}
+	return s, nil
+}
+
+// MarshalJSON encodes the set as a JSON array.
+// When the element type is ordered (integers, floats and strings), the array is sorted
+// so that the output is deterministic.
+//
+// Example:
+//
+//	st := set.New(3, 1, 2)
+//	data, err := json.Marshal(st)
+//	require.NoError(err)
+//	require.JSONEq(`[1, 2, 3]`, string(data))
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	// Elements are encoded one by one so that sets of bytes
+	// become arrays rather than base64 strings.
+	buf := bytes.NewBufferString("[")
+	for i, e := range s.orderedSlice() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its contents.
+// Duplicate elements are merged; use the package-level UnmarshalJSON with
+// DisallowDuplicates to reject them instead. A JSON null leaves the set unchanged.
+//
+// Example:
+//
+//	type Config struct {
+//		Tags set.Set[string] `json:"tags"`
+//	}
+//	var config Config
+//	err := json.Unmarshal([]byte(`{"tags": ["a", "b", "a"]}`), &config)
+//	require.NoError(err)
+//	require.True(config.Tags.Equals(set.New("a", "b")))
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	decoded, err := UnmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	*s = decoded
+	return nil
+}
+
+// MarshalText encodes a set of string-like elements as a sorted, comma-separated list.
+// Elements containing commas or quotes are quoted as in CSV.
+// Returns ErrNotStringLike for other element types.
+//
+// Example:
+//
+//	st := set.New("b", "a", "c,d")
+//	text, err := st.MarshalText()
+//	require.NoError(err)
+//	require.Equal(`a,b,"c,d"`, string(text))
+func (s Set[T]) MarshalText() ([]byte, error) {
+	if !isStringLike[T]() {
+		return nil, ErrNotStringLike
+	}
+	record := make([]string, 0, s.Len())
+	for _, e := range s.orderedSlice() {
+		record = append(record, reflect.ValueOf(e).String())
+	}
+	if len(record) == 1 && record[0] == "" {
+		return []byte(`""`), nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(record); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalText decodes a comma-separated list produced by MarshalText into the set,
+// replacing its contents. Returns ErrNotStringLike for non string-like element types.
+//
+// Example:
+//
+//	var st set.Set[string]
+//	err := st.UnmarshalText([]byte(`a,b,"c,d",a`))
+//	require.NoError(err)
+//	require.True(st.Equals(set.New("a", "b", "c,d")))
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	if !isStringLike[T]() {
+		return ErrNotStringLike
+	}
+	decoded := New[T]()
+	if len(text) > 0 {
+		r := csv.NewReader(bytes.NewReader(text))
+		r.FieldsPerRecord = -1
+		record, err := r.Read()
+		if err != nil {
+			return err
+		}
+		for _, field := range record {
+			var e T
+			reflect.ValueOf(&e).Elem().SetString(field)
+			decoded.Insert(e)
+		}
+	}
+	*s = decoded
+	return nil
+}
+
+// GobEncode encodes the set for transmission by encoding/gob.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	err := gob.NewEncoder(&buf).Encode(set.New(Pos{1, 2}, Pos{3, 4}))
+//	require.NoError(err)
+//
+//	var decoded set.Set[Pos]
+//	err = gob.NewDecoder(&buf).Decode(&decoded)
+//	require.NoError(err)
+//	require.True(decoded.Equals(set.New(Pos{1, 2}, Pos{3, 4})))
+func (s Set[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s.orderedSlice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a set encoded by GobEncode, replacing its contents.
+//
+// Example:
+//
+//	original := set.New("a", "b")
+//	data, err := original.GobEncode()
+//	require.NoError(err)
+//
+//	var decoded set.Set[string]
+//	err = decoded.GobDecode(data)
+//	require.NoError(err)
+//	require.True(decoded.Equals(original))
+func (s *Set[T]) GobDecode(data []byte) error {
+	var elements []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&elements); err != nil {
+		return err
+	}
+	*s = New(elements...)
+	return nil
+}
+
+// orderedSlice returns the elements of the set, sorted when the element type is ordered.
+func (s Set[T]) orderedSlice() []T {
+	elements := s.ToSlice()
+	if compare, ok := orderedCompare[T](); ok {
+		slices.SortFunc(elements, compare)
+	}
+	return elements
+}
+
+// orderedCompare returns a comparison function for T when its underlying type
+// satisfies cmp.Ordered.
+func orderedCompare[T any]() (func(a, b T) int, bool) {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}, true
+	case reflect.Float32, reflect.Float64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}, true
+	case reflect.String:
+		return func(a, b T) int {
+			return strings.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func isStringLike[T any]() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.String
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
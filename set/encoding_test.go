@@ -0,0 +1,243 @@
+package set_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type EncodingSuite struct {
+	suite.Suite
+}
+
+func TestEncodingSuite(t *testing.T) {
+	suite.Run(t, new(EncodingSuite))
+}
+
+type Pos struct{ X, Y int }
+
+type ID string
+
+func (s *EncodingSuite) TestUnmarshalJSON() {
+	require := require.New(s.T())
+	// testdoc begin UnmarshalJSON
+	st, err := set.UnmarshalJSON[int]([]byte(`[1, 2, 3]`))
+	require.NoError(err)
+	require.True(st.Equals(set.New(1, 2, 3)))
+
+	_, err = set.UnmarshalJSON[int]([]byte(`[1, 2, 1]`), set.DisallowDuplicates())
+	require.ErrorIs(err, set.ErrDuplicateElement)
+	// testdoc end
+
+	st, err = set.UnmarshalJSON[int]([]byte(`[1, 2, 1]`))
+	require.NoError(err)
+	require.True(st.Equals(set.New(1, 2)))
+
+	_, err = set.UnmarshalJSON[int]([]byte(`{"a": 1}`))
+	require.Error(err)
+
+	ps, err := set.UnmarshalJSON[Pos]([]byte(`[{"X":1,"Y":2},{"X":1,"Y":2}]`), set.DisallowDuplicates())
+	require.ErrorIs(err, set.ErrDuplicateElement)
+	require.True(ps.IsEmpty())
+}
+
+func (s *EncodingSuite) TestMarshalJSON() {
+	require := require.New(s.T())
+	// testdoc begin Set.MarshalJSON
+	st := set.New(3, 1, 2)
+	data, err := json.Marshal(st)
+	require.NoError(err)
+	require.JSONEq(`[1, 2, 3]`, string(data))
+	// testdoc end
+	require.Equal(`[1,2,3]`, string(data))
+
+	data, err = json.Marshal(set.New[string]())
+	require.NoError(err)
+	require.Equal(`[]`, string(data))
+
+	data, err = json.Marshal(set.Set[string]{})
+	require.NoError(err)
+	require.Equal(`[]`, string(data))
+
+	// named string types are sorted too
+	data, err = json.Marshal(set.New[ID]("c", "a", "b"))
+	require.NoError(err)
+	require.Equal(`["a","b","c"]`, string(data))
+
+	data, err = json.Marshal(set.New(2.5, -1.0, 0.0))
+	require.NoError(err)
+	require.Equal(`[-1,0,2.5]`, string(data))
+
+	data, err = json.Marshal(set.New[uint16](9, 3))
+	require.NoError(err)
+	require.Equal(`[3,9]`, string(data))
+}
+
+func (s *EncodingSuite) TestSetUnmarshalJSON() {
+	require := require.New(s.T())
+	// testdoc begin Set.UnmarshalJSON
+	type Config struct {
+		Tags set.Set[string] `json:"tags"`
+	}
+	var config Config
+	err := json.Unmarshal([]byte(`{"tags": ["a", "b", "a"]}`), &config)
+	require.NoError(err)
+	require.True(config.Tags.Equals(set.New("a", "b")))
+	// testdoc end
+
+	// null is a no-op
+	require.NoError(json.Unmarshal([]byte(`{"tags": null}`), &config))
+	require.True(config.Tags.Equals(set.New("a", "b")))
+
+	// existing contents are replaced
+	require.NoError(json.Unmarshal([]byte(`{"tags": ["c"]}`), &config))
+	require.True(config.Tags.Equals(set.New("c")))
+
+	require.Error(json.Unmarshal([]byte(`{"tags": [1]}`), &config))
+}
+
+func (s *EncodingSuite) TestJSONRoundTrip() {
+	require := require.New(s.T())
+
+	type Doc struct {
+		Name      string       `json:"name"`
+		Positions set.Set[Pos] `json:"positions"`
+	}
+	doc := Doc{Name: "doc", Positions: set.New(Pos{1, 2}, Pos{3, 4})}
+	data, err := json.Marshal(doc)
+	require.NoError(err)
+
+	var decoded Doc
+	require.NoError(json.Unmarshal(data, &decoded))
+	require.Equal("doc", decoded.Name)
+	require.True(decoded.Positions.Equals(doc.Positions))
+}
+
+func (s *EncodingSuite) TestMarshalText() {
+	require := require.New(s.T())
+	// testdoc begin Set.MarshalText
+	st := set.New("b", "a", "c,d")
+	text, err := st.MarshalText()
+	require.NoError(err)
+	require.Equal(`a,b,"c,d"`, string(text))
+	// testdoc end
+
+	text, err = set.New[string]().MarshalText()
+	require.NoError(err)
+	require.Equal(``, string(text))
+
+	text, err = set.New("").MarshalText()
+	require.NoError(err)
+	require.Equal(`""`, string(text))
+
+	_, err = set.New(1).MarshalText()
+	require.ErrorIs(err, set.ErrNotStringLike)
+}
+
+func (s *EncodingSuite) TestUnmarshalText() {
+	require := require.New(s.T())
+	// testdoc begin Set.UnmarshalText
+	var st set.Set[string]
+	err := st.UnmarshalText([]byte(`a,b,"c,d",a`))
+	require.NoError(err)
+	require.True(st.Equals(set.New("a", "b", "c,d")))
+	// testdoc end
+
+	var is set.Set[int]
+	require.ErrorIs(is.UnmarshalText([]byte(`1`)), set.ErrNotStringLike)
+
+	require.Error(st.UnmarshalText([]byte(`"unterminated`)))
+}
+
+func (s *EncodingSuite) TestTextRoundTrip() {
+	require := require.New(s.T())
+
+	for _, original := range []set.Set[ID]{
+		set.New[ID](),
+		set.New[ID](""),
+		set.New[ID]("", "x"),
+		set.New[ID]("plain", "with,comma", `with"quote`, "with\nnewline", " padded "),
+	} {
+		text, err := original.MarshalText()
+		require.NoError(err)
+
+		var decoded set.Set[ID]
+		require.NoError(decoded.UnmarshalText(text))
+		require.True(decoded.Equals(original), "text=%q", text)
+	}
+}
+
+func (s *EncodingSuite) TestGobEncode() {
+	require := require.New(s.T())
+	// testdoc begin Set.GobEncode
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(set.New(Pos{1, 2}, Pos{3, 4}))
+	require.NoError(err)
+
+	var decoded set.Set[Pos]
+	err = gob.NewDecoder(&buf).Decode(&decoded)
+	require.NoError(err)
+	require.True(decoded.Equals(set.New(Pos{1, 2}, Pos{3, 4})))
+	// testdoc end
+}
+
+func (s *EncodingSuite) TestGobDecode() {
+	require := require.New(s.T())
+	// testdoc begin Set.GobDecode
+	original := set.New("a", "b")
+	data, err := original.GobEncode()
+	require.NoError(err)
+
+	var decoded set.Set[string]
+	err = decoded.GobDecode(data)
+	require.NoError(err)
+	require.True(decoded.Equals(original))
+	// testdoc end
+
+	require.Error(decoded.GobDecode([]byte("garbage")))
+}
+
+func (s *EncodingSuite) TestGobStruct() {
+	require := require.New(s.T())
+
+	type Doc struct {
+		Name string
+		Tags set.Set[string]
+	}
+	var buf bytes.Buffer
+	require.NoError(gob.NewEncoder(&buf).Encode(Doc{Name: "doc", Tags: set.New("x", "y")}))
+
+	var decoded Doc
+	require.NoError(gob.NewDecoder(&buf).Decode(&decoded))
+	require.Equal("doc", decoded.Name)
+	require.True(decoded.Tags.Equals(set.New("x", "y")))
+}
+
+func (s *EncodingSuite) TestDisallowDuplicates() {
+	require := require.New(s.T())
+	// testdoc begin DisallowDuplicates
+	_, err := set.UnmarshalJSON[string]([]byte(`["a", "a"]`), set.DisallowDuplicates())
+	require.ErrorIs(err, set.ErrDuplicateElement)
+	// testdoc end
+}
+
+func (s *EncodingSuite) TestJSONBytes() {
+	require := require.New(s.T())
+
+	data, err := json.Marshal(set.New[byte](9, 3))
+	require.NoError(err)
+	require.Equal(`[3,9]`, string(data))
+
+	var decoded set.Set[byte]
+	require.NoError(json.Unmarshal(data, &decoded))
+	require.True(decoded.Equals(set.New[byte](3, 9)))
+
+	_, err = json.Marshal(set.New[any](make(chan int)))
+	require.Error(err)
+}
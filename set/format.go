@@ -0,0 +1,134 @@
+package set
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	_ fmt.Stringer   = Set[int]{}
+	_ fmt.Formatter  = Set[int]{}
+	_ slog.LogValuer = Set[int]{}
+)
+
+// LogLimit is the number of elements a set logs through slog before truncating.
+// A negative value disables truncation.
+var LogLimit = 20
+
+// String returns the elements of the set in braces, such as {1, 2, 3}.
+// Elements are sorted by value when the element type is ordered,
+// and by their formatted text otherwise, so the output is deterministic.
+//
+// Example:
+//
+//	st := set.New(3, 1, 2)
+//	require.Equal("{1, 2, 3}", st.String())
+//	require.Equal("{}", set.New[string]().String())
+func (s Set[T]) String() string {
+	return fmt.Sprint(s)
+}
+
+// Format implements fmt.Formatter.
+// The verb and flags are applied to each element, so %q quotes string elements
+// and %x prints integers in hexadecimal. %#v prints a Go expression that builds the set.
+//
+// Example:
+//
+//	st := set.New("b", "a")
+//	require.Equal("{a, b}", fmt.Sprintf("%v", st))
+//	require.Equal(`{"a", "b"}`, fmt.Sprintf("%q", st))
+//	require.Equal(`set.New[string]("a", "b")`, fmt.Sprintf("%#v", st))
+//	require.Equal("{a, ff}", fmt.Sprintf("%x", set.New(255, 10)))
+func (s Set[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "set.New[%s](%s)", reflect.TypeFor[T](), strings.Join(s.formatted("%#v"), ", "))
+		return
+	}
+	fmt.Fprintf(f, "{%s}", strings.Join(s.formatted(fmt.FormatString(f, verb)), ", "))
+}
+
+// LogValue implements slog.LogValuer, logging the set as an array of at most LogLimit elements.
+// When elements are omitted, the last entry of the array tells how many.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+//		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+//			if a.Key == slog.TimeKey {
+//				return slog.Attr{}
+//			}
+//			return a
+//		},
+//	}))
+//	logger.Info("tags", "tags", set.New("b", "a"))
+//	require.JSONEq(`{"level":"INFO","msg":"tags","tags":["a","b"]}`, buf.String())
+func (s Set[T]) LogValue() slog.Value {
+	return LogValue(s, LogLimit)
+}
+
+// LogValue returns a slog value that logs s as an array of at most limit elements,
+// in the same order as String. A negative limit disables truncation.
+//
+// Example:
+//
+//	st := set.New(5, 4, 3, 2, 1)
+//	require.Equal([]int{1, 2, 3, 4, 5}, set.LogValue(st, -1).Any())
+//	require.Equal([]any{1, 2, "... 3 more"}, set.LogValue(st, 2).Any())
+func LogValue[T comparable](s Set[T], limit int) slog.Value {
+	elements := s.displayOrder()
+	if limit < 0 || len(elements) <= limit {
+		return slog.AnyValue(elements)
+	}
+	truncated := make([]any, 0, limit+1)
+	for _, e := range elements[:limit] {
+		truncated = append(truncated, e)
+	}
+	truncated = append(truncated, fmt.Sprintf("... %d more", len(elements)-limit))
+	return slog.AnyValue(truncated)
+}
+
+// formatted returns the elements of the set formatted with format, in display order.
+func (s Set[T]) formatted(format string) []string {
+	if _, ok := orderedCompare[T](); ok {
+		elements := s.orderedSlice()
+		texts := make([]string, len(elements))
+		for i, e := range elements {
+			texts[i] = fmt.Sprintf(format, e)
+		}
+		return texts
+	}
+	texts := make([]string, 0, s.Len())
+	for e := range s.data {
+		texts = append(texts, fmt.Sprintf(format, e))
+	}
+	slices.Sort(texts)
+	return texts
+}
+
+// displayOrder returns the elements of the set sorted by value when the element type is ordered,
+// and by their %v text otherwise.
+func (s Set[T]) displayOrder() []T {
+	if _, ok := orderedCompare[T](); ok {
+		return s.orderedSlice()
+	}
+	type keyed struct {
+		key     string
+		element T
+	}
+	entries := make([]keyed, 0, s.Len())
+	for e := range s.data {
+		entries = append(entries, keyed{fmt.Sprint(e), e})
+	}
+	slices.SortFunc(entries, func(a, b keyed) int { return strings.Compare(a.key, b.key) })
+	elements := make([]T, len(entries))
+	for i, entry := range entries {
+		elements[i] = entry.element
+	}
+	return elements
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
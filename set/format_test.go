@@ -0,0 +1,109 @@
+package set_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type FormatSuite struct {
+	suite.Suite
+}
+
+func TestFormatSuite(t *testing.T) {
+	suite.Run(t, new(FormatSuite))
+}
+
+func (s *FormatSuite) TestString() {
+	require := require.New(s.T())
+	// testdoc begin Set.String
+	st := set.New(3, 1, 2)
+	require.Equal("{1, 2, 3}", st.String())
+	require.Equal("{}", set.New[string]().String())
+	// testdoc end
+
+	// non-ordered elements are sorted by their text
+	require.Equal("{{1 2}, {1 3}, {2 0}}", set.New(Pos{2, 0}, Pos{1, 3}, Pos{1, 2}).String())
+	require.Equal("{-1.5, 2, 10}", set.New(10.0, -1.5, 2.0).String())
+	require.Equal("{a, b}", set.New[ID]("b", "a").String())
+}
+
+func (s *FormatSuite) TestFormat() {
+	require := require.New(s.T())
+	// testdoc begin Set.Format
+	st := set.New("b", "a")
+	require.Equal("{a, b}", fmt.Sprintf("%v", st))
+	require.Equal(`{"a", "b"}`, fmt.Sprintf("%q", st))
+	require.Equal(`set.New[string]("a", "b")`, fmt.Sprintf("%#v", st))
+	require.Equal("{a, ff}", fmt.Sprintf("%x", set.New(255, 10)))
+	// testdoc end
+
+	require.Equal("{1, 2}", fmt.Sprintf("%d", set.New(2, 1)))
+	require.Equal("{  1,   2}", fmt.Sprintf("%3d", set.New(2, 1)))
+	require.Equal("set.New[int]()", fmt.Sprintf("%#v", set.New[int]()))
+	require.Equal("set.New[set_test.Pos](set_test.Pos{X:1, Y:2})", fmt.Sprintf("%#v", set.New(Pos{1, 2})))
+	require.Equal("set.New[interface {}](1)", fmt.Sprintf("%#v", set.New[any](1)))
+	require.Equal("{{X:1 Y:2}}", fmt.Sprintf("%+v", set.New(Pos{1, 2})))
+
+	// deterministic across runs and insertion orders
+	for range 10 {
+		require.Equal("{x, y, z}", fmt.Sprint(set.New("z", "x", "y")))
+	}
+
+	// used as a field of another value
+	type Config struct {
+		Tags set.Set[string]
+	}
+	require.Equal("{Tags:{a, b}}", fmt.Sprintf("%+v", Config{set.New("b", "a")}))
+}
+
+func (s *FormatSuite) TestLogValue() {
+	require := require.New(s.T())
+	// testdoc begin Set.LogValue
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("tags", "tags", set.New("b", "a"))
+	require.JSONEq(`{"level":"INFO","msg":"tags","tags":["a","b"]}`, buf.String())
+	// testdoc end
+
+	large := set.New[int]()
+	for i := range 100 {
+		large.Insert(i)
+	}
+	buf.Reset()
+	logger.Info("ids", "ids", large)
+	require.JSONEq(`{"level":"INFO","msg":"ids","ids":[0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,"... 80 more"]}`, buf.String())
+
+	original := set.LogLimit
+	defer func() { set.LogLimit = original }()
+	set.LogLimit = 3
+	require.Equal([]any{0, 1, 2, "... 97 more"}, large.LogValue().Any())
+	set.LogLimit = -1
+	require.Len(large.LogValue().Any(), 100)
+}
+
+func (s *FormatSuite) TestLogValueFunc() {
+	require := require.New(s.T())
+	// testdoc begin LogValue
+	st := set.New(5, 4, 3, 2, 1)
+	require.Equal([]int{1, 2, 3, 4, 5}, set.LogValue(st, -1).Any())
+	require.Equal([]any{1, 2, "... 3 more"}, set.LogValue(st, 2).Any())
+	// testdoc end
+
+	require.Equal([]any{"... 5 more"}, set.LogValue(st, 0).Any())
+	require.Equal([]int{1, 2, 3, 4, 5}, set.LogValue(st, 5).Any())
+	require.Equal([]Pos{{1, 2}, {2, 1}}, set.LogValue(set.New(Pos{2, 1}, Pos{1, 2}), 10).Any())
+}
@@ -0,0 +1,149 @@
+package set
+
+// Map creates a new set by applying f to every element of s.
+// Elements mapped to the same value are merged. The source set is not modified.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3)
+//	s2 := set.Map(s1, strconv.Itoa)
+//	require.True(s2.Equals(set.New("1", "2", "3")))
+//	require.True(s1.Equals(set.New(1, 2, 3))) // s1 should be unchanged
+func Map[T, U comparable](s Set[T], f func(T) U) Set[U] {
+	result := New[U]()
+	for e := range s.data {
+		result.Insert(f(e))
+	}
+	return result
+}
+
+// Filter creates a new set containing the elements of s that satisfy pred.
+// The source set is not modified.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.Filter(s1, func(e int) bool { return e%2 == 0 })
+//	require.True(s2.Equals(set.New(2, 4)))
+//	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+func Filter[T comparable](s Set[T], pred func(T) bool) Set[T] {
+	result := New[T]()
+	for e := range s.data {
+		if pred(e) {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+// Reduce folds the elements of s into a single value, starting from initial.
+// The iteration order is not specified, so f should be commutative.
+//
+// Example:
+//
+//	st := set.New(1, 2, 3, 4)
+//	sum := set.Reduce(st, 0, func(acc, e int) int { return acc + e })
+//	require.Equal(10, sum)
+func Reduce[T comparable, A any](s Set[T], initial A, f func(acc A, element T) A) A {
+	acc := initial
+	for e := range s.data {
+		acc = f(acc, e)
+	}
+	return acc
+}
+
+// Any reports whether at least one element of s satisfies pred.
+// It returns false for an empty set.
+//
+// Example:
+//
+//	st := set.New(1, 3, 4)
+//	require.True(set.Any(st, func(e int) bool { return e%2 == 0 }))
+//	require.False(set.Any(st, func(e int) bool { return e > 10 }))
+func Any[T comparable](s Set[T], pred func(T) bool) bool {
+	for e := range s.data {
+		if pred(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether every element of s satisfies pred.
+// It returns true for an empty set.
+//
+// Example:
+//
+//	st := set.New(2, 4, 6)
+//	require.True(set.All(st, func(e int) bool { return e%2 == 0 }))
+//	require.False(set.All(st, func(e int) bool { return e > 2 }))
+func All[T comparable](s Set[T], pred func(T) bool) bool {
+	for e := range s.data {
+		if !pred(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// None reports whether no element of s satisfies pred.
+// It returns true for an empty set.
+//
+// Example:
+//
+//	st := set.New(1, 3, 5)
+//	require.True(set.None(st, func(e int) bool { return e%2 == 0 }))
+//	require.False(set.None(st, func(e int) bool { return e == 3 }))
+func None[T comparable](s Set[T], pred func(T) bool) bool {
+	return !Any(s, pred)
+}
+
+// Partition splits s into two new sets: the elements that satisfy pred and those that do not.
+// The source set is not modified.
+//
+// Example:
+//
+//	st := set.New(1, 2, 3, 4, 5)
+//	even, odd := set.Partition(st, func(e int) bool { return e%2 == 0 })
+//	require.True(even.Equals(set.New(2, 4)))
+//	require.True(odd.Equals(set.New(1, 3, 5)))
+//	require.True(st.Equals(set.New(1, 2, 3, 4, 5))) // st should be unchanged
+func Partition[T comparable](s Set[T], pred func(T) bool) (matched Set[T], unmatched Set[T]) {
+	matched = New[T]()
+	unmatched = New[T]()
+	for e := range s.data {
+		if pred(e) {
+			matched.Insert(e)
+		} else {
+			unmatched.Insert(e)
+		}
+	}
+	return matched, unmatched
+}
+
+// GroupBy groups the elements of s into new sets keyed by the result of key.
+// The source set is not modified.
+//
+// Example:
+//
+//	st := set.New("apple", "avocado", "banana", "cherry")
+//	groups := set.GroupBy(st, func(e string) byte { return e[0] })
+//	require.Len(groups, 3)
+//	require.True(groups['a'].Equals(set.New("apple", "avocado")))
+//	require.True(groups['b'].Equals(set.New("banana")))
+//	require.True(groups['c'].Equals(set.New("cherry")))
+func GroupBy[T, K comparable](s Set[T], key func(T) K) map[K]Set[T] {
+	groups := make(map[K]Set[T])
+	for e := range s.data {
+		k := key(e)
+		group, ok := groups[k]
+		if !ok {
+			group = New[T]()
+			groups[k] = group
+		}
+		group.Insert(e)
+	}
+	return groups
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
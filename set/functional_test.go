@@ -0,0 +1,119 @@
+package set_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type FunctionalSuite struct {
+	suite.Suite
+}
+
+func TestFunctionalSuite(t *testing.T) {
+	suite.Run(t, new(FunctionalSuite))
+}
+
+func isEven(e int) bool { return e%2 == 0 }
+
+func (s *FunctionalSuite) TestMap() {
+	require := require.New(s.T())
+	// testdoc begin Map
+	s1 := set.New(1, 2, 3)
+	s2 := set.Map(s1, strconv.Itoa)
+	require.True(s2.Equals(set.New("1", "2", "3")))
+	require.True(s1.Equals(set.New(1, 2, 3))) // s1 should be unchanged
+	// testdoc end
+
+	// colliding images are merged
+	s3 := set.Map(set.New(-2, -1, 1, 2), func(e int) int { return e * e })
+	require.True(s3.Equals(set.New(1, 4)))
+
+	require.True(set.Map(set.New[int](), strconv.Itoa).IsEmpty())
+}
+
+func (s *FunctionalSuite) TestFilter() {
+	require := require.New(s.T())
+	// testdoc begin Filter
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.Filter(s1, func(e int) bool { return e%2 == 0 })
+	require.True(s2.Equals(set.New(2, 4)))
+	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+	// testdoc end
+
+	require.True(set.Filter(s1, func(int) bool { return false }).IsEmpty())
+}
+
+func (s *FunctionalSuite) TestReduce() {
+	require := require.New(s.T())
+	// testdoc begin Reduce
+	st := set.New(1, 2, 3, 4)
+	sum := set.Reduce(st, 0, func(acc, e int) int { return acc + e })
+	require.Equal(10, sum)
+	// testdoc end
+
+	require.Equal("init", set.Reduce(set.New[int](), "init", func(acc string, e int) string { return acc + strconv.Itoa(e) }))
+}
+
+func (s *FunctionalSuite) TestAny() {
+	require := require.New(s.T())
+	// testdoc begin Any
+	st := set.New(1, 3, 4)
+	require.True(set.Any(st, func(e int) bool { return e%2 == 0 }))
+	require.False(set.Any(st, func(e int) bool { return e > 10 }))
+	// testdoc end
+	require.False(set.Any(set.New[int](), isEven))
+}
+
+func (s *FunctionalSuite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin All
+	st := set.New(2, 4, 6)
+	require.True(set.All(st, func(e int) bool { return e%2 == 0 }))
+	require.False(set.All(st, func(e int) bool { return e > 2 }))
+	// testdoc end
+	require.True(set.All(set.New[int](), isEven))
+}
+
+func (s *FunctionalSuite) TestNone() {
+	require := require.New(s.T())
+	// testdoc begin None
+	st := set.New(1, 3, 5)
+	require.True(set.None(st, func(e int) bool { return e%2 == 0 }))
+	require.False(set.None(st, func(e int) bool { return e == 3 }))
+	// testdoc end
+	require.True(set.None(set.New[int](), isEven))
+}
+
+func (s *FunctionalSuite) TestPartition() {
+	require := require.New(s.T())
+	// testdoc begin Partition
+	st := set.New(1, 2, 3, 4, 5)
+	even, odd := set.Partition(st, func(e int) bool { return e%2 == 0 })
+	require.True(even.Equals(set.New(2, 4)))
+	require.True(odd.Equals(set.New(1, 3, 5)))
+	require.True(st.Equals(set.New(1, 2, 3, 4, 5))) // st should be unchanged
+	// testdoc end
+
+	matched, unmatched := set.Partition(set.New[int](), isEven)
+	require.True(matched.IsEmpty())
+	require.True(unmatched.IsEmpty())
+}
+
+func (s *FunctionalSuite) TestGroupBy() {
+	require := require.New(s.T())
+	// testdoc begin GroupBy
+	st := set.New("apple", "avocado", "banana", "cherry")
+	groups := set.GroupBy(st, func(e string) byte { return e[0] })
+	require.Len(groups, 3)
+	require.True(groups['a'].Equals(set.New("apple", "avocado")))
+	require.True(groups['b'].Equals(set.New("banana")))
+	require.True(groups['c'].Equals(set.New("cherry")))
+	// testdoc end
+
+	require.Empty(set.GroupBy(set.New[int](), isEven))
+}
@@ -0,0 +1,241 @@
+package set
+
+import "reflect"
+
+// IntersectWith removes the elements that are not in the other set, modifying the current set.
+// It does not allocate.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.New(3, 4, 5, 6)
+//	s1.IntersectWith(s2)
+//	require.True(s1.Equals(set.New(3, 4)))
+//	require.True(s2.Equals(set.New(3, 4, 5, 6))) // s2 should be unchanged
+func (s *Set[T]) IntersectWith(other Set[T]) {
+	for e := range s.data {
+		if !other.Contains(e) {
+			delete(s.data, e)
+		}
+	}
+}
+
+// DifferenceWith removes the elements that are in the other set, modifying the current set.
+// It does not allocate.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.New(3, 4, 5, 6)
+//	s1.DifferenceWith(s2)
+//	require.True(s1.Equals(set.New(1, 2)))
+//	require.True(s2.Equals(set.New(3, 4, 5, 6))) // s2 should be unchanged
+func (s *Set[T]) DifferenceWith(other Set[T]) {
+	if sameData(*s, other) {
+		clear(s.data)
+		return
+	}
+	if other.Len() < s.Len() {
+		for e := range other.data {
+			delete(s.data, e)
+		}
+		return
+	}
+	for e := range s.data {
+		if other.Contains(e) {
+			delete(s.data, e)
+		}
+	}
+}
+
+// SymmetricDifferenceWith keeps the elements in either set but not in both, modifying the current set.
+// It allocates only when the set grows beyond its current capacity.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.New(3, 4, 5, 6)
+//	s1.SymmetricDifferenceWith(s2)
+//	require.True(s1.Equals(set.New(1, 2, 5, 6)))
+//	require.True(s2.Equals(set.New(3, 4, 5, 6))) // s2 should be unchanged
+func (s *Set[T]) SymmetricDifferenceWith(other Set[T]) {
+	if sameData(*s, other) {
+		clear(s.data)
+		return
+	}
+	for e := range other.data {
+		if s.Contains(e) {
+			delete(s.data, e)
+		} else {
+			s.data[e] = exists{}
+		}
+	}
+}
+
+// RetainFunc removes the elements that do not satisfy pred, modifying the current set.
+//
+// Example:
+//
+//	st := set.New(1, 2, 3, 4)
+//	st.RetainFunc(func(e int) bool { return e%2 == 0 })
+//	require.True(st.Equals(set.New(2, 4)))
+func (s *Set[T]) RetainFunc(pred func(T) bool) {
+	for e := range s.data {
+		if !pred(e) {
+			delete(s.data, e)
+		}
+	}
+}
+
+// RemoveFunc removes the elements that satisfy pred, modifying the current set.
+//
+// Example:
+//
+//	st := set.New(1, 2, 3, 4)
+//	st.RemoveFunc(func(e int) bool { return e%2 == 0 })
+//	require.True(st.Equals(set.New(1, 3)))
+func (s *Set[T]) RemoveFunc(pred func(T) bool) {
+	for e := range s.data {
+		if pred(e) {
+			delete(s.data, e)
+		}
+	}
+}
+
+// UnionInto replaces the contents of dst with the union of the current set and another set.
+// dst keeps its capacity, so reusing it across calls avoids allocation.
+// dst may be the current set or the other set.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3)
+//	s2 := set.New(3, 4, 5)
+//	dst := set.New(9)
+//	s1.UnionInto(s2, &dst)
+//	require.True(dst.Equals(set.New(1, 2, 3, 4, 5)))
+//	require.True(s1.Equals(set.New(1, 2, 3))) // s1 should be unchanged
+func (s Set[T]) UnionInto(other Set[T], dst *Set[T]) {
+	switch {
+	case sameData(*dst, s):
+		dst.Merge(other)
+	case sameData(*dst, other):
+		dst.Merge(s)
+	default:
+		dst.reset()
+		dst.Merge(s)
+		dst.Merge(other)
+	}
+}
+
+// IntersectionInto replaces the contents of dst with the intersection of the current set and another set.
+// dst keeps its capacity, so reusing it across calls avoids allocation.
+// dst may be the current set or the other set.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.New(3, 4, 5, 6)
+//	dst := set.New(9)
+//	s1.IntersectionInto(s2, &dst)
+//	require.True(dst.Equals(set.New(3, 4)))
+//	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+func (s Set[T]) IntersectionInto(other Set[T], dst *Set[T]) {
+	switch {
+	case sameData(*dst, s):
+		dst.IntersectWith(other)
+	case sameData(*dst, other):
+		dst.IntersectWith(s)
+	default:
+		dst.reset()
+		small, large := s, other
+		if large.Len() < small.Len() {
+			small, large = large, small
+		}
+		for e := range small.data {
+			if large.Contains(e) {
+				dst.data[e] = exists{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements in the current set but not in the other set.
+// dst keeps its capacity, so reusing it across calls avoids allocation.
+// dst may be the current set, or the other set at the cost of a temporary set.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.New(3, 4, 5, 6)
+//	dst := set.New(9)
+//	s1.DifferenceInto(s2, &dst)
+//	require.True(dst.Equals(set.New(1, 2)))
+//	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+func (s Set[T]) DifferenceInto(other Set[T], dst *Set[T]) {
+	switch {
+	case sameData(*dst, s):
+		dst.DifferenceWith(other)
+	case sameData(*dst, other):
+		// the result shares no element with other, so it is collected before other is cleared
+		result := s.Difference(other)
+		dst.reset()
+		dst.Merge(result)
+	default:
+		dst.reset()
+		for e := range s.data {
+			if !other.Contains(e) {
+				dst.data[e] = exists{}
+			}
+		}
+	}
+}
+
+// SymmetricDifferenceInto replaces the contents of dst with the elements in either set but not in both.
+// dst keeps its capacity, so reusing it across calls avoids allocation.
+// dst may be the current set or the other set.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.New(3, 4, 5, 6)
+//	dst := set.New(9)
+//	s1.SymmetricDifferenceInto(s2, &dst)
+//	require.True(dst.Equals(set.New(1, 2, 5, 6)))
+//	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+func (s Set[T]) SymmetricDifferenceInto(other Set[T], dst *Set[T]) {
+	switch {
+	case sameData(*dst, s):
+		dst.SymmetricDifferenceWith(other)
+	case sameData(*dst, other):
+		dst.SymmetricDifferenceWith(s)
+	default:
+		dst.reset()
+		for e := range s.data {
+			if !other.Contains(e) {
+				dst.data[e] = exists{}
+			}
+		}
+		for e := range other.data {
+			if !s.Contains(e) {
+				dst.data[e] = exists{}
+			}
+		}
+	}
+}
+
+// reset removes all elements while keeping the allocated capacity,
+// initializing the map of a zero Set.
+func (s *Set[T]) reset() {
+	if s.data == nil {
+		s.data = make(map[T]exists)
+		return
+	}
+	clear(s.data)
+}
+
+// sameData reports whether two sets share the same underlying map.
+func sameData[T comparable](a, b Set[T]) bool {
+	return a.data != nil && reflect.ValueOf(a.data).UnsafePointer() == reflect.ValueOf(b.data).UnsafePointer()
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,341 @@
+package set_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type InPlaceSuite struct {
+	suite.Suite
+}
+
+func TestInPlaceSuite(t *testing.T) {
+	suite.Run(t, new(InPlaceSuite))
+}
+
+func (s *InPlaceSuite) TestIntersectWith() {
+	require := require.New(s.T())
+	// testdoc begin Set.IntersectWith
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.New(3, 4, 5, 6)
+	s1.IntersectWith(s2)
+	require.True(s1.Equals(set.New(3, 4)))
+	require.True(s2.Equals(set.New(3, 4, 5, 6))) // s2 should be unchanged
+	// testdoc end
+
+	s1.IntersectWith(s1)
+	require.True(s1.Equals(set.New(3, 4)))
+	s1.IntersectWith(set.New[int]())
+	require.True(s1.IsEmpty())
+}
+
+func (s *InPlaceSuite) TestDifferenceWith() {
+	require := require.New(s.T())
+	// testdoc begin Set.DifferenceWith
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.New(3, 4, 5, 6)
+	s1.DifferenceWith(s2)
+	require.True(s1.Equals(set.New(1, 2)))
+	require.True(s2.Equals(set.New(3, 4, 5, 6))) // s2 should be unchanged
+	// testdoc end
+
+	// the smaller set is iterated either way
+	s3 := set.New(1, 2, 3, 4, 5, 6)
+	s3.DifferenceWith(set.New(2))
+	require.True(s3.Equals(set.New(1, 3, 4, 5, 6)))
+
+	s3.DifferenceWith(s3)
+	require.True(s3.IsEmpty())
+}
+
+func (s *InPlaceSuite) TestSymmetricDifferenceWith() {
+	require := require.New(s.T())
+	// testdoc begin Set.SymmetricDifferenceWith
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.New(3, 4, 5, 6)
+	s1.SymmetricDifferenceWith(s2)
+	require.True(s1.Equals(set.New(1, 2, 5, 6)))
+	require.True(s2.Equals(set.New(3, 4, 5, 6))) // s2 should be unchanged
+	// testdoc end
+
+	s1.SymmetricDifferenceWith(s1)
+	require.True(s1.IsEmpty())
+}
+
+func (s *InPlaceSuite) TestRetainFunc() {
+	require := require.New(s.T())
+	// testdoc begin Set.RetainFunc
+	st := set.New(1, 2, 3, 4)
+	st.RetainFunc(func(e int) bool { return e%2 == 0 })
+	require.True(st.Equals(set.New(2, 4)))
+	// testdoc end
+
+	st.RetainFunc(func(int) bool { return false })
+	require.True(st.IsEmpty())
+}
+
+func (s *InPlaceSuite) TestRemoveFunc() {
+	require := require.New(s.T())
+	// testdoc begin Set.RemoveFunc
+	st := set.New(1, 2, 3, 4)
+	st.RemoveFunc(func(e int) bool { return e%2 == 0 })
+	require.True(st.Equals(set.New(1, 3)))
+	// testdoc end
+
+	st.RemoveFunc(func(int) bool { return false })
+	require.True(st.Equals(set.New(1, 3)))
+}
+
+func (s *InPlaceSuite) TestUnionInto() {
+	require := require.New(s.T())
+	// testdoc begin Set.UnionInto
+	s1 := set.New(1, 2, 3)
+	s2 := set.New(3, 4, 5)
+	dst := set.New(9)
+	s1.UnionInto(s2, &dst)
+	require.True(dst.Equals(set.New(1, 2, 3, 4, 5)))
+	require.True(s1.Equals(set.New(1, 2, 3))) // s1 should be unchanged
+	// testdoc end
+
+	var zero set.Set[int]
+	s1.UnionInto(s2, &zero)
+	require.True(zero.Equals(set.New(1, 2, 3, 4, 5)))
+}
+
+func (s *InPlaceSuite) TestIntersectionInto() {
+	require := require.New(s.T())
+	// testdoc begin Set.IntersectionInto
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.New(3, 4, 5, 6)
+	dst := set.New(9)
+	s1.IntersectionInto(s2, &dst)
+	require.True(dst.Equals(set.New(3, 4)))
+	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *InPlaceSuite) TestDifferenceInto() {
+	require := require.New(s.T())
+	// testdoc begin Set.DifferenceInto
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.New(3, 4, 5, 6)
+	dst := set.New(9)
+	s1.DifferenceInto(s2, &dst)
+	require.True(dst.Equals(set.New(1, 2)))
+	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *InPlaceSuite) TestSymmetricDifferenceInto() {
+	require := require.New(s.T())
+	// testdoc begin Set.SymmetricDifferenceInto
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.New(3, 4, 5, 6)
+	dst := set.New(9)
+	s1.SymmetricDifferenceInto(s2, &dst)
+	require.True(dst.Equals(set.New(1, 2, 5, 6)))
+	require.True(s1.Equals(set.New(1, 2, 3, 4))) // s1 should be unchanged
+	// testdoc end
+}
+
+// TestMatchesAllocating checks every in-place form against its allocating counterpart,
+// including destinations that alias an operand.
+func (s *InPlaceSuite) TestMatchesAllocating() {
+	require := require.New(s.T())
+
+	type op struct {
+		name       string
+		allocating func(a, b set.Set[int]) set.Set[int]
+		with       func(a *set.Set[int], b set.Set[int])
+		into       func(a, b set.Set[int], dst *set.Set[int])
+	}
+	ops := []op{
+		{"Union", set.Set[int].Union, (*set.Set[int]).Merge, set.Set[int].UnionInto},
+		{"Intersection", set.Set[int].Intersection, (*set.Set[int]).IntersectWith, set.Set[int].IntersectionInto},
+		{"Difference", set.Set[int].Difference, (*set.Set[int]).DifferenceWith, set.Set[int].DifferenceInto},
+		{"SymmetricDifference", set.Set[int].SymmetricDifference, (*set.Set[int]).SymmetricDifferenceWith, set.Set[int].SymmetricDifferenceInto},
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	random := func() set.Set[int] {
+		st := set.New[int]()
+		for range r.IntN(20) {
+			st.Insert(r.IntN(30))
+		}
+		return st
+	}
+
+	for range 200 {
+		a, b := random(), random()
+		for _, op := range ops {
+			expected := op.allocating(a, b)
+
+			with := a.Copy()
+			op.with(&with, b)
+			require.True(with.Equals(expected), op.name)
+
+			dst := random()
+			op.into(a, b, &dst)
+			require.True(dst.Equals(expected), op.name)
+
+			dstA := a.Copy()
+			op.into(dstA, b, &dstA)
+			require.True(dstA.Equals(expected), op.name+" into first operand")
+
+			dstB := b.Copy()
+			op.into(a, dstB, &dstB)
+			require.True(dstB.Equals(expected), op.name+" into second operand")
+
+			self := a.Copy()
+			op.into(self, self, &self)
+			require.True(self.Equals(op.allocating(a, a)), op.name+" of a set with itself")
+		}
+
+		retained := a.Copy()
+		retained.RetainFunc(func(e int) bool { return e%3 == 0 })
+		removed := a.Copy()
+		removed.RemoveFunc(func(e int) bool { return e%3 == 0 })
+		require.True(retained.Equals(set.Filter(a, func(e int) bool { return e%3 == 0 })))
+		require.True(removed.Equals(a.Difference(retained)))
+	}
+}
+
+func (s *InPlaceSuite) TestZeroAllocations() {
+	require := require.New(s.T())
+
+	a, b := benchSets()
+	dst := set.New[int]()
+	a.UnionInto(b, &dst) // grow dst to its largest size once
+
+	require.Zero(testing.AllocsPerRun(10, func() {
+		a.UnionInto(b, &dst)
+		a.IntersectionInto(b, &dst)
+		a.DifferenceInto(b, &dst)
+		a.SymmetricDifferenceInto(b, &dst)
+	}))
+
+	work := a.Copy()
+	work.Merge(b)
+	require.Zero(testing.AllocsPerRun(10, func() {
+		work.IntersectWith(b)
+		work.Merge(a)
+		work.DifferenceWith(b)
+		work.Merge(b)
+		work.RetainFunc(func(e int) bool { return e%2 == 0 })
+		work.Merge(a)
+		work.RemoveFunc(func(e int) bool { return e%2 == 0 })
+		work.Merge(b)
+	}))
+}
+
+const benchSize = 10000
+
+// benchSets returns two overlapping sets of benchSize elements each.
+func benchSets() (set.Set[int], set.Set[int]) {
+	a, b := set.New[int](), set.New[int]()
+	for i := range benchSize {
+		a.Insert(i)
+		b.Insert(i + benchSize/2)
+	}
+	return a, b
+}
+
+func BenchmarkIntersection(b *testing.B) {
+	s1, s2 := benchSets()
+	b.ReportAllocs()
+	for b.Loop() {
+		s1.Intersection(s2)
+	}
+}
+
+func BenchmarkIntersectionInto(b *testing.B) {
+	s1, s2 := benchSets()
+	dst := set.New[int]()
+	b.ReportAllocs()
+	for b.Loop() {
+		s1.IntersectionInto(s2, &dst)
+	}
+}
+
+// BenchmarkIntersectWith restores the removed elements with Merge in every iteration.
+func BenchmarkIntersectWith(b *testing.B) {
+	s1, s2 := benchSets()
+	work := s1.Copy()
+	b.ReportAllocs()
+	for b.Loop() {
+		work.IntersectWith(s2)
+		work.Merge(s1)
+	}
+}
+
+func BenchmarkDifference(b *testing.B) {
+	s1, s2 := benchSets()
+	b.ReportAllocs()
+	for b.Loop() {
+		s1.Difference(s2)
+	}
+}
+
+func BenchmarkDifferenceInto(b *testing.B) {
+	s1, s2 := benchSets()
+	dst := set.New[int]()
+	b.ReportAllocs()
+	for b.Loop() {
+		s1.DifferenceInto(s2, &dst)
+	}
+}
+
+// BenchmarkDifferenceWith restores the removed elements with Merge in every iteration.
+func BenchmarkDifferenceWith(b *testing.B) {
+	s1, s2 := benchSets()
+	work := s1.Copy()
+	b.ReportAllocs()
+	for b.Loop() {
+		work.DifferenceWith(s2)
+		work.Merge(s1)
+	}
+}
+
+func BenchmarkSymmetricDifference(b *testing.B) {
+	s1, s2 := benchSets()
+	b.ReportAllocs()
+	for b.Loop() {
+		s1.SymmetricDifference(s2)
+	}
+}
+
+func BenchmarkSymmetricDifferenceInto(b *testing.B) {
+	s1, s2 := benchSets()
+	dst := set.New[int]()
+	b.ReportAllocs()
+	for b.Loop() {
+		s1.SymmetricDifferenceInto(s2, &dst)
+	}
+}
+
+// BenchmarkSymmetricDifferenceWith applies the operation twice per iteration,
+// which restores the original set.
+func BenchmarkSymmetricDifferenceWith(b *testing.B) {
+	s1, s2 := benchSets()
+	work := s1.Copy()
+	b.ReportAllocs()
+	for b.Loop() {
+		work.SymmetricDifferenceWith(s2)
+		work.SymmetricDifferenceWith(s2)
+	}
+}
+
+func BenchmarkRetainFunc(b *testing.B) {
+	s1, _ := benchSets()
+	work := s1.Copy()
+	b.ReportAllocs()
+	for b.Loop() {
+		work.RetainFunc(func(e int) bool { return e%2 == 0 })
+		work.Merge(s1)
+	}
+}
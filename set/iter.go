@@ -0,0 +1,103 @@
+package set
+
+import (
+	"iter"
+	"slices"
+)
+
+// Collect creates a new Set from the elements yielded by seq.
+//
+// Example:
+//
+//	st := set.Collect(slices.Values([]int{1, 2, 2, 3}))
+//	require.True(st.Equals(set.New(1, 2, 3)))
+func Collect[T comparable](seq iter.Seq[T]) Set[T] {
+	s := New[T]()
+	s.InsertSeq(seq)
+	return s
+}
+
+// All returns an iterator over the elements of the set.
+// The iteration order is not specified and may differ between calls.
+//
+// Example:
+//
+//	st := set.New(1, 2, 3)
+//	sum := 0
+//	for e := range st.All() {
+//		sum += e
+//	}
+//	require.Equal(6, sum)
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range s.data {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns an iterator over the elements of the set ordered by cmp.
+// cmp follows the convention of slices.SortFunc.
+//
+// Example:
+//
+//	st := set.New(3, 1, 2)
+//	require.Equal([]int{1, 2, 3}, slices.Collect(st.Sorted(cmp.Compare[int])))
+func (s Set[T]) Sorted(cmp func(a, b T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		elements := s.ToSlice()
+		slices.SortFunc(elements, cmp)
+		for _, element := range elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Filtered returns an iterator over the elements of the set that satisfy pred.
+//
+// Example:
+//
+//	st := set.New(1, 2, 3, 4)
+//	even := set.Collect(st.Filtered(func(e int) bool { return e%2 == 0 }))
+//	require.True(even.Equals(set.New(2, 4)))
+func (s Set[T]) Filtered(pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range s.data {
+			if pred(element) && !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// InsertSeq adds all elements yielded by seq to the set.
+//
+// Example:
+//
+//	st := set.New(1)
+//	st.InsertSeq(slices.Values([]int{2, 3}))
+//	require.True(st.Equals(set.New(1, 2, 3)))
+func (s *Set[T]) InsertSeq(seq iter.Seq[T]) {
+	for element := range seq {
+		s.data[element] = exists{}
+	}
+}
+
+// RemoveSeq deletes all elements yielded by seq from the set.
+//
+// Example:
+//
+//	st := set.New(1, 2, 3)
+//	st.RemoveSeq(slices.Values([]int{1, 3, 5}))
+//	require.True(st.Equals(set.New(2)))
+func (s *Set[T]) RemoveSeq(seq iter.Seq[T]) {
+	for element := range seq {
+		delete(s.data, element)
+	}
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,111 @@
+package set_test
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type IterSuite struct {
+	suite.Suite
+}
+
+func TestIterSuite(t *testing.T) {
+	suite.Run(t, new(IterSuite))
+}
+
+func (s *IterSuite) TestCollect() {
+	require := require.New(s.T())
+	// testdoc begin Collect
+	st := set.Collect(slices.Values([]int{1, 2, 2, 3}))
+	require.True(st.Equals(set.New(1, 2, 3)))
+	// testdoc end
+	require.True(set.Collect(maps.Keys(map[string]int{"a": 1, "b": 2})).Equals(set.New("a", "b")))
+	require.True(set.Collect(slices.Values([]int{})).IsEmpty())
+}
+
+func (s *IterSuite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin Set.All
+	st := set.New(1, 2, 3)
+	sum := 0
+	for e := range st.All() {
+		sum += e
+	}
+	require.Equal(6, sum)
+	// testdoc end
+	require.ElementsMatch([]int{1, 2, 3}, slices.Collect(st.All()))
+
+	// early break stops the iteration
+	count := 0
+	for range st.All() {
+		count++
+		break
+	}
+	require.Equal(1, count)
+
+	require.Empty(slices.Collect(set.New[int]().All()))
+}
+
+func (s *IterSuite) TestSorted() {
+	require := require.New(s.T())
+	// testdoc begin Set.Sorted
+	st := set.New(3, 1, 2)
+	require.Equal([]int{1, 2, 3}, slices.Collect(st.Sorted(cmp.Compare[int])))
+	// testdoc end
+	desc := func(a, b int) int { return cmp.Compare(b, a) }
+	require.Equal([]int{3, 2, 1}, slices.Collect(st.Sorted(desc)))
+
+	var first []int
+	for e := range st.Sorted(cmp.Compare[int]) {
+		first = append(first, e)
+		if len(first) == 2 {
+			break
+		}
+	}
+	require.Equal([]int{1, 2}, first)
+}
+
+func (s *IterSuite) TestFiltered() {
+	require := require.New(s.T())
+	// testdoc begin Set.Filtered
+	st := set.New(1, 2, 3, 4)
+	even := set.Collect(st.Filtered(func(e int) bool { return e%2 == 0 }))
+	require.True(even.Equals(set.New(2, 4)))
+	// testdoc end
+
+	count := 0
+	for range st.Filtered(func(int) bool { return true }) {
+		count++
+		break
+	}
+	require.Equal(1, count)
+}
+
+func (s *IterSuite) TestInsertSeq() {
+	require := require.New(s.T())
+	// testdoc begin Set.InsertSeq
+	st := set.New(1)
+	st.InsertSeq(slices.Values([]int{2, 3}))
+	require.True(st.Equals(set.New(1, 2, 3)))
+	// testdoc end
+	st.InsertSeq(set.New(3, 4).All())
+	require.True(st.Equals(set.New(1, 2, 3, 4)))
+}
+
+func (s *IterSuite) TestRemoveSeq() {
+	require := require.New(s.T())
+	// testdoc begin Set.RemoveSeq
+	st := set.New(1, 2, 3)
+	st.RemoveSeq(slices.Values([]int{1, 3, 5}))
+	require.True(st.Equals(set.New(2)))
+	// testdoc end
+	st.RemoveSeq(st.All())
+	require.True(st.IsEmpty())
+}
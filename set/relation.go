@@ -0,0 +1,153 @@
+package set
+
+// IsSubsetOf checks if every element of the set is also in the other set.
+//
+// Example:
+//
+//	s1 := set.New(1, 2)
+//	s2 := set.New(1, 2, 3)
+//	require.True(s1.IsSubsetOf(s2))
+//	require.True(s1.IsSubsetOf(s1))
+//	require.False(s2.IsSubsetOf(s1))
+func (s Set[T]) IsSubsetOf(other Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for e := range s.data {
+		if !other.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsProperSubsetOf checks if the set is a subset of the other set and the two sets are not equal.
+//
+// Example:
+//
+//	s1 := set.New(1, 2)
+//	s2 := set.New(1, 2, 3)
+//	require.True(s1.IsProperSubsetOf(s2))
+//	require.False(s1.IsProperSubsetOf(s1))
+func (s Set[T]) IsProperSubsetOf(other Set[T]) bool {
+	return s.Len() < other.Len() && s.IsSubsetOf(other)
+}
+
+// IsSupersetOf checks if the set contains every element of the other set.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3)
+//	s2 := set.New(1, 2)
+//	require.True(s1.IsSupersetOf(s2))
+//	require.True(s1.IsSupersetOf(s1))
+//	require.False(s2.IsSupersetOf(s1))
+func (s Set[T]) IsSupersetOf(other Set[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// IsProperSupersetOf checks if the set is a superset of the other set and the two sets are not equal.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3)
+//	s2 := set.New(1, 2)
+//	require.True(s1.IsProperSupersetOf(s2))
+//	require.False(s1.IsProperSupersetOf(s1))
+func (s Set[T]) IsProperSupersetOf(other Set[T]) bool {
+	return other.IsProperSubsetOf(s)
+}
+
+// IsDisjoint checks if the set and the other set have no elements in common.
+// It iterates over the smaller of the two sets.
+//
+// Example:
+//
+//	s1 := set.New(1, 2)
+//	s2 := set.New(3, 4)
+//	s3 := set.New(2, 3)
+//	require.True(s1.IsDisjoint(s2))
+//	require.False(s1.IsDisjoint(s3))
+func (s Set[T]) IsDisjoint(other Set[T]) bool {
+	smaller, larger := s, other
+	if smaller.Len() > larger.Len() {
+		smaller, larger = larger, smaller
+	}
+	for e := range smaller.data {
+		if larger.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionAll creates a new set that is the union of all given sets.
+// The largest set is copied first so that only the remaining sets are rehashed.
+// None of the given sets are modified.
+//
+// Example:
+//
+//	s1 := set.New(1, 2)
+//	s2 := set.New(2, 3)
+//	s3 := set.New(4)
+//	u := set.UnionAll(s1, s2, s3)
+//	require.True(u.Equals(set.New(1, 2, 3, 4)))
+//	require.True(s1.Equals(set.New(1, 2))) // s1 should be unchanged
+func UnionAll[T comparable](sets ...Set[T]) Set[T] {
+	if len(sets) == 0 {
+		return New[T]()
+	}
+	largest := 0
+	for i, st := range sets {
+		if st.Len() > sets[largest].Len() {
+			largest = i
+		}
+	}
+	result := sets[largest].Copy()
+	for i, st := range sets {
+		if i != largest {
+			result.Merge(st)
+		}
+	}
+	return result
+}
+
+// IntersectAll creates a new set that is the intersection of all given sets.
+// The smallest set drives the iteration, so the cost is bounded by its size.
+// Returns an empty set when no sets are given. None of the given sets are modified.
+//
+// Example:
+//
+//	s1 := set.New(1, 2, 3, 4)
+//	s2 := set.New(2, 3, 4, 5)
+//	s3 := set.New(3, 4, 6)
+//	i := set.IntersectAll(s1, s2, s3)
+//	require.True(i.Equals(set.New(3, 4)))
+//	require.True(s3.Equals(set.New(3, 4, 6))) // s3 should be unchanged
+func IntersectAll[T comparable](sets ...Set[T]) Set[T] {
+	result := New[T]()
+	if len(sets) == 0 {
+		return result
+	}
+	smallest := 0
+	for i, st := range sets {
+		if st.Len() < sets[smallest].Len() {
+			smallest = i
+		}
+	}
+	for e := range sets[smallest].data {
+		inAll := true
+		for i, st := range sets {
+			if i != smallest && !st.Contains(e) {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			result.Insert(e)
+		}
+	}
+	return result
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,123 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+type RelationSuite struct {
+	suite.Suite
+}
+
+func TestRelationSuite(t *testing.T) {
+	suite.Run(t, new(RelationSuite))
+}
+
+func (s *RelationSuite) TestIsSubsetOf() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsSubsetOf
+	s1 := set.New(1, 2)
+	s2 := set.New(1, 2, 3)
+	require.True(s1.IsSubsetOf(s2))
+	require.True(s1.IsSubsetOf(s1))
+	require.False(s2.IsSubsetOf(s1))
+	// testdoc end
+	require.True(set.New[int]().IsSubsetOf(s1))
+	require.True(set.New[int]().IsSubsetOf(set.New[int]()))
+	require.False(set.New(1, 4).IsSubsetOf(s2))
+}
+
+func (s *RelationSuite) TestIsProperSubsetOf() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsProperSubsetOf
+	s1 := set.New(1, 2)
+	s2 := set.New(1, 2, 3)
+	require.True(s1.IsProperSubsetOf(s2))
+	require.False(s1.IsProperSubsetOf(s1))
+	// testdoc end
+	require.False(s2.IsProperSubsetOf(s1))
+	require.True(set.New[int]().IsProperSubsetOf(s1))
+	require.False(set.New[int]().IsProperSubsetOf(set.New[int]()))
+}
+
+func (s *RelationSuite) TestIsSupersetOf() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsSupersetOf
+	s1 := set.New(1, 2, 3)
+	s2 := set.New(1, 2)
+	require.True(s1.IsSupersetOf(s2))
+	require.True(s1.IsSupersetOf(s1))
+	require.False(s2.IsSupersetOf(s1))
+	// testdoc end
+	require.True(s1.IsSupersetOf(set.New[int]()))
+	require.False(s1.IsSupersetOf(set.New(1, 4)))
+}
+
+func (s *RelationSuite) TestIsProperSupersetOf() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsProperSupersetOf
+	s1 := set.New(1, 2, 3)
+	s2 := set.New(1, 2)
+	require.True(s1.IsProperSupersetOf(s2))
+	require.False(s1.IsProperSupersetOf(s1))
+	// testdoc end
+	require.False(s2.IsProperSupersetOf(s1))
+}
+
+func (s *RelationSuite) TestIsDisjoint() {
+	require := require.New(s.T())
+	// testdoc begin Set.IsDisjoint
+	s1 := set.New(1, 2)
+	s2 := set.New(3, 4)
+	s3 := set.New(2, 3)
+	require.True(s1.IsDisjoint(s2))
+	require.False(s1.IsDisjoint(s3))
+	// testdoc end
+	require.True(s1.IsDisjoint(set.New[int]()))
+	require.True(set.New[int]().IsDisjoint(set.New[int]()))
+	// larger receiver
+	require.False(set.New(1, 2, 3, 4, 5).IsDisjoint(set.New(5)))
+}
+
+func (s *RelationSuite) TestUnionAll() {
+	require := require.New(s.T())
+	// testdoc begin UnionAll
+	s1 := set.New(1, 2)
+	s2 := set.New(2, 3)
+	s3 := set.New(4)
+	u := set.UnionAll(s1, s2, s3)
+	require.True(u.Equals(set.New(1, 2, 3, 4)))
+	require.True(s1.Equals(set.New(1, 2))) // s1 should be unchanged
+	// testdoc end
+	require.True(set.UnionAll[int]().IsEmpty())
+
+	// result must not alias the largest input
+	large := set.New(1, 2, 3, 4, 5)
+	u = set.UnionAll(s3, large)
+	u.Insert(6)
+	require.True(large.Equals(set.New(1, 2, 3, 4, 5)))
+}
+
+func (s *RelationSuite) TestIntersectAll() {
+	require := require.New(s.T())
+	// testdoc begin IntersectAll
+	s1 := set.New(1, 2, 3, 4)
+	s2 := set.New(2, 3, 4, 5)
+	s3 := set.New(3, 4, 6)
+	i := set.IntersectAll(s1, s2, s3)
+	require.True(i.Equals(set.New(3, 4)))
+	require.True(s3.Equals(set.New(3, 4, 6))) // s3 should be unchanged
+	// testdoc end
+	require.True(set.IntersectAll[int]().IsEmpty())
+	require.True(set.IntersectAll(s1, set.New[int]()).IsEmpty())
+
+	// result must not alias a single input
+	single := set.New(1, 2)
+	i = set.IntersectAll(single)
+	i.Insert(3)
+	require.True(single.Equals(set.New(1, 2)))
+}
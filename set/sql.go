@@ -0,0 +1,264 @@
+package set
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ driver.Valuer = Set[string]{}
+	_ sql.Scanner   = (*Set[string])(nil)
+)
+
+// ErrInvalidArray is returned when scanning a malformed PostgreSQL array literal.
+var ErrInvalidArray = errors.New("set: invalid array literal")
+
+// ErrUnsupportedElement is returned by the PostgreSQL array encoding for element types
+// other than strings, integers and floats.
+var ErrUnsupportedElement = errors.New("set: unsupported element type for array literal")
+
+// Value implements driver.Valuer, storing the set as a JSON array string
+// in the same format as MarshalJSON.
+//
+// Example:
+//
+//	v, err := set.New("b", "a").Value()
+//	require.NoError(err)
+//	require.Equal(`["a","b"]`, v)
+func (s Set[T]) Value() (driver.Value, error) {
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set.
+// It accepts a JSON array or a PostgreSQL array literal as a string or []byte.
+// A NULL value scans as an empty set.
+//
+// Example:
+//
+//	var st set.Set[int]
+//	err := st.Scan(`[3, 1, 2]`)
+//	require.NoError(err)
+//	require.True(st.Equals(set.New(1, 2, 3)))
+//
+//	err = st.Scan([]byte(`{4,5}`))
+//	require.NoError(err)
+//	require.True(st.Equals(set.New(4, 5)))
+func (s *Set[T]) Scan(src any) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*s = New[T]()
+		return nil
+	case string:
+		data = []byte(src)
+	case []byte:
+		data = src
+	default:
+		return fmt.Errorf("set: cannot scan %T into Set[%s]", src, reflect.TypeFor[T]())
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoded, err := parseArray[T](string(trimmed))
+		if err != nil {
+			return err
+		}
+		*s = decoded
+		return nil
+	}
+	decoded, err := UnmarshalJSON[T](data)
+	if err != nil {
+		return err
+	}
+	*s = decoded
+	return nil
+}
+
+// PostgresArray wraps a set so that it is stored as a PostgreSQL array literal such as {a,b,c}
+// instead of a JSON array. Use it both as a query argument and as a Scan destination.
+// Elements must be strings, integers or floats.
+//
+// Example:
+//
+//	st := set.New("b", "a", "c d")
+//	v, err := set.PostgresArray(&st).Value()
+//	require.NoError(err)
+//	require.Equal(`{"a","b","c d"}`, v)
+//
+//	var scanned set.Set[string]
+//	err = set.PostgresArray(&scanned).Scan(v)
+//	require.NoError(err)
+//	require.True(scanned.Equals(st))
+func PostgresArray[T comparable](s *Set[T]) interface {
+	driver.Valuer
+	sql.Scanner
+} {
+	return postgresArray[T]{s}
+}
+
+type postgresArray[T comparable] struct {
+	set *Set[T]
+}
+
+func (a postgresArray[T]) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range a.set.orderedSlice() {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		v := reflect.ValueOf(e)
+		switch v.Kind() {
+		case reflect.String:
+			b.WriteString(quoteArrayElement(v.String()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			b.WriteString(strconv.FormatInt(v.Int(), 10))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			b.WriteString(strconv.FormatUint(v.Uint(), 10))
+		case reflect.Float32, reflect.Float64:
+			b.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedElement, v.Type())
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
+func (a postgresArray[T]) Scan(src any) error {
+	return a.set.Scan(src)
+}
+
+// quoteArrayElement quotes a string for a PostgreSQL array literal, escaping quotes and backslashes.
+func quoteArrayElement(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// parseArray decodes a one-dimensional PostgreSQL array literal into a set.
+func parseArray[T comparable](literal string) (Set[T], error) {
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return Set[T]{}, fmt.Errorf("%w: %q", ErrInvalidArray, literal)
+	}
+	body := literal[1 : len(literal)-1]
+	s := New[T]()
+	if strings.TrimSpace(body) == "" {
+		return s, nil
+	}
+	for i := 0; ; {
+		field, quoted, next, err := nextArrayField(body, i)
+		if err != nil {
+			return Set[T]{}, fmt.Errorf("%w: %q: %w", ErrInvalidArray, literal, err)
+		}
+		if !quoted && strings.EqualFold(field, "NULL") {
+			return Set[T]{}, fmt.Errorf("%w: %q: NULL element", ErrInvalidArray, literal)
+		}
+		e, err := parseElement[T](field)
+		if err != nil {
+			return Set[T]{}, err
+		}
+		s.Insert(e)
+		if next >= len(body) {
+			return s, nil
+		}
+		i = next + 1 // skip the comma
+	}
+}
+
+// nextArrayField reads the field of an array literal body starting at i.
+// It returns the unescaped field, whether it was quoted, and the index of the following comma
+// or the end of the body.
+func nextArrayField(body string, i int) (string, bool, int, error) {
+	for i < len(body) && body[i] == ' ' {
+		i++
+	}
+	if i < len(body) && body[i] == '"' {
+		var b strings.Builder
+		for i++; i < len(body); i++ {
+			switch c := body[i]; c {
+			case '\\':
+				i++
+				if i == len(body) {
+					return "", false, 0, errors.New("unterminated escape")
+				}
+				b.WriteByte(body[i])
+			case '"':
+				i++
+				for i < len(body) && body[i] == ' ' {
+					i++
+				}
+				if i < len(body) && body[i] != ',' {
+					return "", false, 0, errors.New("unexpected character after quoted element")
+				}
+				return b.String(), true, i, nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", false, 0, errors.New("unterminated quoted element")
+	}
+	end := strings.IndexByte(body[i:], ',')
+	if end < 0 {
+		end = len(body)
+	} else {
+		end += i
+	}
+	field := strings.TrimSpace(body[i:end])
+	if field == "" {
+		return "", false, 0, errors.New("empty element")
+	}
+	if strings.ContainsAny(field, `{}"\`) {
+		return "", false, 0, errors.New("nested or malformed element")
+	}
+	return field, false, end, nil
+}
+
+// parseElement converts the text of an array element to T.
+func parseElement[T comparable](field string) (T, error) {
+	var e T
+	v := reflect.ValueOf(&e).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(field)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(field, 10, v.Type().Bits())
+		if err != nil {
+			return e, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(field, 10, v.Type().Bits())
+		if err != nil {
+			return e, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(field, v.Type().Bits())
+		if err != nil {
+			return e, err
+		}
+		v.SetFloat(f)
+	default:
+		return e, fmt.Errorf("%w: %s", ErrUnsupportedElement, v.Type())
+	}
+	return e, nil
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
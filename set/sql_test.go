@@ -0,0 +1,242 @@
+package set_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+// stubDriver is an in-memory database/sql driver with a single text column per key.
+// It understands two statements: "PUT" with (key, value) arguments and "GET" with a key argument.
+type stubDriver struct {
+	mu   sync.Mutex
+	rows map[string]driver.Value
+}
+
+var stub = &stubDriver{rows: map[string]driver.Value{}}
+
+func init() {
+	sql.Register("setstub", stub)
+}
+
+func (d *stubDriver) Open(string) (driver.Conn, error) { return stubConn{d}, nil }
+
+type stubConn struct{ d *stubDriver }
+
+func (c stubConn) Prepare(query string) (driver.Stmt, error) { return stubStmt{c.d, query}, nil }
+func (c stubConn) Close() error                              { return nil }
+func (c stubConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type stubStmt struct {
+	d     *stubDriver
+	query string
+}
+
+func (s stubStmt) Close() error  { return nil }
+func (s stubStmt) NumInput() int { return -1 }
+
+func (s stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.rows[args[0].(string)] = args[1]
+	return driver.RowsAffected(1), nil
+}
+
+func (s stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	v, ok := s.d.rows[args[0].(string)]
+	return &stubRows{value: v, done: !ok}, nil
+}
+
+type stubRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *stubRows) Columns() []string { return []string{"value"} }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	// drivers commonly return text columns as []byte
+	if s, ok := r.value.(string); ok {
+		dest[0] = []byte(s)
+	} else {
+		dest[0] = r.value
+	}
+	return nil
+}
+
+type SQLSuite struct {
+	suite.Suite
+	db *sql.DB
+}
+
+func TestSQLSuite(t *testing.T) {
+	suite.Run(t, new(SQLSuite))
+}
+
+func (s *SQLSuite) SetupTest() {
+	db, err := sql.Open("setstub", "")
+	s.Require().NoError(err)
+	s.db = db
+}
+
+func (s *SQLSuite) TearDownTest() {
+	s.Require().NoError(s.db.Close())
+}
+
+func (s *SQLSuite) TestValue() {
+	require := require.New(s.T())
+	// testdoc begin Set.Value
+	v, err := set.New("b", "a").Value()
+	require.NoError(err)
+	require.Equal(`["a","b"]`, v)
+	// testdoc end
+
+	v, err = set.New[int]().Value()
+	require.NoError(err)
+	require.Equal(`[]`, v)
+}
+
+func (s *SQLSuite) TestScan() {
+	require := require.New(s.T())
+	// testdoc begin Set.Scan
+	var st set.Set[int]
+	err := st.Scan(`[3, 1, 2]`)
+	require.NoError(err)
+	require.True(st.Equals(set.New(1, 2, 3)))
+
+	err = st.Scan([]byte(`{4,5}`))
+	require.NoError(err)
+	require.True(st.Equals(set.New(4, 5)))
+	// testdoc end
+
+	require.NoError(st.Scan(nil))
+	require.True(st.IsEmpty())
+	require.Error(st.Scan(42))
+	require.Error(st.Scan(`["a"]`))
+	require.Error(st.Scan(`{a}`))
+}
+
+func (s *SQLSuite) TestRoundTrip() {
+	require := require.New(s.T())
+
+	tags := set.New("go", "sql", "sets")
+	_, err := s.db.Exec("PUT", "tags", tags)
+	require.NoError(err)
+
+	var scanned set.Set[string]
+	require.NoError(s.db.QueryRow("GET", "tags").Scan(&scanned))
+	require.True(scanned.Equals(tags))
+	require.Equal(`["go","sets","sql"]`, stub.rows["tags"])
+
+	ids := set.New[int64](3, -1, 2)
+	_, err = s.db.Exec("PUT", "ids", ids)
+	require.NoError(err)
+	var scannedIDs set.Set[int64]
+	require.NoError(s.db.QueryRow("GET", "ids").Scan(&scannedIDs))
+	require.True(scannedIDs.Equals(ids))
+
+	_, err = s.db.Exec("PUT", "null", nil)
+	require.NoError(err)
+	scanned = set.New("stale")
+	require.NoError(s.db.QueryRow("GET", "null").Scan(&scanned))
+	require.True(scanned.IsEmpty())
+}
+
+func (s *SQLSuite) TestPostgresArray() {
+	require := require.New(s.T())
+	// testdoc begin PostgresArray
+	st := set.New("b", "a", "c d")
+	v, err := set.PostgresArray(&st).Value()
+	require.NoError(err)
+	require.Equal(`{"a","b","c d"}`, v)
+
+	var scanned set.Set[string]
+	err = set.PostgresArray(&scanned).Scan(v)
+	require.NoError(err)
+	require.True(scanned.Equals(st))
+	// testdoc end
+
+	tricky := set.New(`quote"`, `back\slash`, "comma,", "{brace}", "", "NULL")
+	_, err = s.db.Exec("PUT", "tricky", set.PostgresArray(&tricky))
+	require.NoError(err)
+	require.Equal(`{"","NULL","back\\slash","comma,","quote\"","{brace}"}`, stub.rows["tricky"])
+	var back set.Set[string]
+	require.NoError(s.db.QueryRow("GET", "tricky").Scan(set.PostgresArray(&back)))
+	require.True(back.Equals(tricky))
+
+	nums := set.New(2.5, -1.0, 1e21)
+	v, err = set.PostgresArray(&nums).Value()
+	require.NoError(err)
+	require.Equal(`{-1,2.5,1e+21}`, v)
+
+	u8 := set.New[uint8](255, 0)
+	v, err = set.PostgresArray(&u8).Value()
+	require.NoError(err)
+	require.Equal(`{0,255}`, v)
+
+	pos := set.New(Pos{1, 2})
+	_, err = set.PostgresArray(&pos).Value()
+	require.ErrorIs(err, set.ErrUnsupportedElement)
+}
+
+func (s *SQLSuite) TestParseArrayLiteral() {
+	require := require.New(s.T())
+
+	var st set.Set[string]
+	for literal, expected := range map[string]set.Set[string]{
+		`{}`:                  set.New[string](),
+		` { } `:               set.New[string](),
+		`{a,b,c}`:             set.New("a", "b", "c"),
+		`{ a , b }`:           set.New("a", "b"),
+		`{"a b", c,"a b"}`:    set.New("a b", "c"),
+		`{"x\"y","\\"}`:       set.New(`x"y`, `\`),
+		`{"null"}`:            set.New("null"),
+		`{"",  "" }`:          set.New(""),
+		`{hello world,"ok" }`: set.New("hello world", "ok"),
+	} {
+		require.NoError(st.Scan(literal), literal)
+		require.True(st.Equals(expected), literal)
+	}
+
+	for _, literal := range []string{
+		`{`,
+		`{a,,b}`,
+		`{a,}`,
+		`{NULL}`,
+		`{null,a}`,
+		`{{a},{b}}`,
+		`{"a}`,
+		`{"a"b}`,
+		`{"a\}`,
+		`{a"b}`,
+	} {
+		require.ErrorIs(st.Scan(literal), set.ErrInvalidArray, literal)
+	}
+
+	var ints set.Set[int8]
+	require.NoError(ints.Scan(`{1, -2, 127}`))
+	require.True(ints.Equals(set.New[int8](1, -2, 127)))
+	require.Error(ints.Scan(`{128}`))
+	require.Error(ints.Scan(`{x}`))
+
+	var floats set.Set[float32]
+	require.NoError(floats.Scan(`{1.5,2}`))
+	require.True(floats.Equals(set.New[float32](1.5, 2)))
+
+	var pos set.Set[Pos]
+	require.ErrorIs(pos.Scan(`{a}`), set.ErrUnsupportedElement)
+}
@@ -0,0 +1,382 @@
+// Package sync provides a thread-safe set that can be shared between goroutines.
+package sync
+
+import (
+	"hash/maphash"
+	"iter"
+	stdsync "sync"
+
+	"github.com/ysuzuki19/collections-go/set"
+)
+
+// DefaultShards is the number of shards used by New.
+const DefaultShards = 32
+
+type shard[T comparable] struct {
+	mu   stdsync.RWMutex
+	data set.Set[T]
+}
+
+// ConcurrentSet is a generic set that is safe for concurrent use.
+// Elements are distributed over shards, each guarded by its own sync.RWMutex,
+// so that operations on different elements rarely contend.
+type ConcurrentSet[T comparable] struct {
+	seed   maphash.Seed
+	shards []*shard[T]
+}
+
+// New creates a new ConcurrentSet with DefaultShards shards and initializes it with the provided elements.
+//
+// Example:
+//
+//	s1 := sync.New[int]()
+//	s2 := sync.New(1, 2, 3)
+//	require.True(s1.IsEmpty())
+//	require.True(s2.Snapshot().Equals(set.New(1, 2, 3)))
+func New[T comparable](elements ...T) *ConcurrentSet[T] {
+	return NewWithShards(DefaultShards, elements...)
+}
+
+// NewWithShards creates a new ConcurrentSet with the given number of shards,
+// rounded up to a power of two, and initializes it with the provided elements.
+//
+// Example:
+//
+//	st := sync.NewWithShards(4, "a", "b")
+//	require.True(st.Snapshot().Equals(set.New("a", "b")))
+func NewWithShards[T comparable](shards int, elements ...T) *ConcurrentSet[T] {
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	s := &ConcurrentSet[T]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]*shard[T], n),
+	}
+	for i := range s.shards {
+		s.shards[i] = &shard[T]{data: set.New[T]()}
+	}
+	s.Insert(elements...)
+	return s
+}
+
+// FromSet creates a new ConcurrentSet containing the elements of st.
+//
+// Example:
+//
+//	st := sync.FromSet(set.New(1, 2, 3))
+//	require.True(st.Contains(2))
+//	require.Equal(3, st.Len())
+func FromSet[T comparable](st set.Set[T]) *ConcurrentSet[T] {
+	s := New[T]()
+	for e := range st.All() {
+		s.Insert(e)
+	}
+	return s
+}
+
+func (s *ConcurrentSet[T]) shardOf(element T) *shard[T] {
+	h := maphash.Comparable(s.seed, element)
+	return s.shards[h&uint64(len(s.shards)-1)]
+}
+
+// rlockAll read-locks every shard in order and returns a function that releases them.
+func (s *ConcurrentSet[T]) rlockAll() func() {
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+	}
+	return func() {
+		for _, sh := range s.shards {
+			sh.mu.RUnlock()
+		}
+	}
+}
+
+// Insert adds one or more elements to the set.
+//
+// Example:
+//
+//	st := sync.New[int]()
+//	st.Insert(1)
+//	st.Insert(2, 3)
+//	require.True(st.Snapshot().Equals(set.New(1, 2, 3)))
+func (s *ConcurrentSet[T]) Insert(elements ...T) {
+	for _, e := range elements {
+		sh := s.shardOf(e)
+		sh.mu.Lock()
+		sh.data.Insert(e)
+		sh.mu.Unlock()
+	}
+}
+
+// InsertIfAbsent adds the element to the set and reports whether it was newly inserted.
+// The check and the insertion happen atomically.
+//
+// Example:
+//
+//	st := sync.New(1)
+//	require.True(st.InsertIfAbsent(2))
+//	require.False(st.InsertIfAbsent(2))
+//	require.False(st.InsertIfAbsent(1))
+func (s *ConcurrentSet[T]) InsertIfAbsent(element T) bool {
+	_, loaded := s.LoadOrInsert(element)
+	return !loaded
+}
+
+// LoadOrInsert returns the element already stored in the set if present.
+// Otherwise, it inserts the element and returns it.
+// The loaded result is true if the element was already present, false if it was inserted.
+//
+// Example:
+//
+//	st := sync.New("a")
+//	actual, loaded := st.LoadOrInsert("a")
+//	require.Equal("a", actual)
+//	require.True(loaded)
+//	actual, loaded = st.LoadOrInsert("b")
+//	require.Equal("b", actual)
+//	require.False(loaded)
+func (s *ConcurrentSet[T]) LoadOrInsert(element T) (actual T, loaded bool) {
+	sh := s.shardOf(element)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.data.Contains(element) {
+		return element, true
+	}
+	sh.data.Insert(element)
+	return element, false
+}
+
+// Remove deletes one or more elements from the set.
+//
+// Example:
+//
+//	st := sync.New(1, 2, 3, 4)
+//	st.Remove(2)
+//	st.Remove(1, 3, 5)
+//	require.True(st.Snapshot().Equals(set.New(4)))
+func (s *ConcurrentSet[T]) Remove(elements ...T) {
+	for _, e := range elements {
+		sh := s.shardOf(e)
+		sh.mu.Lock()
+		sh.data.Remove(e)
+		sh.mu.Unlock()
+	}
+}
+
+// RemoveIfPresent deletes the element from the set and reports whether it was present.
+// The check and the removal happen atomically.
+//
+// Example:
+//
+//	st := sync.New(1, 2)
+//	require.True(st.RemoveIfPresent(1))
+//	require.False(st.RemoveIfPresent(1))
+func (s *ConcurrentSet[T]) RemoveIfPresent(element T) bool {
+	sh := s.shardOf(element)
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if !sh.data.Contains(element) {
+		return false
+	}
+	sh.data.Remove(element)
+	return true
+}
+
+// Contains checks if the set contains a specific element.
+//
+// Example:
+//
+//	st := sync.New(1, 2, 3)
+//	require.True(st.Contains(1))
+//	require.False(st.Contains(4))
+func (s *ConcurrentSet[T]) Contains(element T) bool {
+	sh := s.shardOf(element)
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.data.Contains(element)
+}
+
+// Len returns the number of elements in the set at a single point in time.
+//
+// Example:
+//
+//	st := sync.New[int]()
+//	require.Equal(0, st.Len())
+//	st.Insert(1, 2, 2)
+//	require.Equal(2, st.Len())
+func (s *ConcurrentSet[T]) Len() int {
+	defer s.rlockAll()()
+	n := 0
+	for _, sh := range s.shards {
+		n += sh.data.Len()
+	}
+	return n
+}
+
+// IsEmpty checks if the set is empty.
+//
+// Example:
+//
+//	st := sync.New[int]()
+//	require.True(st.IsEmpty())
+//	st.Insert(1)
+//	require.False(st.IsEmpty())
+func (s *ConcurrentSet[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Equals checks if two sets are equal.
+// Each set is compared using its own consistent snapshot.
+//
+// Example:
+//
+//	s1 := sync.New(1, 2, 3)
+//	s2 := sync.NewWithShards(2, 3, 2, 1)
+//	require.True(s1.Equals(s2))
+//	require.False(s1.Equals(sync.New(1, 2)))
+func (s *ConcurrentSet[T]) Equals(other *ConcurrentSet[T]) bool {
+	return s.Snapshot().Equals(other.Snapshot())
+}
+
+// Snapshot returns a consistent copy of the set as a set.Set.
+// All shards are read-locked while copying, so no concurrent write is partially observed.
+//
+// Example:
+//
+//	st := sync.New(1, 2)
+//	snapshot := st.Snapshot()
+//	st.Insert(3)
+//	require.True(snapshot.Equals(set.New(1, 2))) // snapshot is unaffected by later writes
+func (s *ConcurrentSet[T]) Snapshot() set.Set[T] {
+	defer s.rlockAll()()
+	result := set.New[T]()
+	for _, sh := range s.shards {
+		result.Merge(sh.data)
+	}
+	return result
+}
+
+// ToSlice converts a consistent snapshot of the set to a slice of its elements.
+//
+// Example:
+//
+//	st := sync.New(1, 2, 3)
+//	require.ElementsMatch([]int{1, 2, 3}, st.ToSlice())
+func (s *ConcurrentSet[T]) ToSlice() []T {
+	return s.Snapshot().ToSlice()
+}
+
+// All returns an iterator over a consistent snapshot of the set.
+// Modifying the set during the iteration does not affect the yielded elements.
+//
+// Example:
+//
+//	st := sync.New(1, 2, 3)
+//	sum := 0
+//	for e := range st.All() {
+//		sum += e
+//		st.Insert(e * 10) // safe: iteration runs over a snapshot
+//	}
+//	require.Equal(6, sum)
+func (s *ConcurrentSet[T]) All() iter.Seq[T] {
+	return s.Snapshot().All()
+}
+
+// Clear removes all elements from the set.
+//
+// Example:
+//
+//	st := sync.New(1, 2)
+//	st.Clear()
+//	require.True(st.IsEmpty())
+func (s *ConcurrentSet[T]) Clear() {
+	for _, sh := range s.shards {
+		sh.mu.Lock()
+		sh.data.Clear()
+		sh.mu.Unlock()
+	}
+}
+
+// Copy creates a shallow copy of the set from a consistent snapshot.
+//
+// Example:
+//
+//	s1 := sync.New(1, 2, 3)
+//	s2 := s1.Copy()
+//	s1.Insert(4)
+//	require.True(s2.Snapshot().Equals(set.New(1, 2, 3)))
+func (s *ConcurrentSet[T]) Copy() *ConcurrentSet[T] {
+	return s.fromSnapshot(s.Snapshot())
+}
+
+// Merge adds all elements from another set into the current set.
+//
+// Example:
+//
+//	s1 := sync.New(1, 2, 3)
+//	s2 := sync.New(3, 4, 5)
+//	s1.Merge(s2)
+//	require.True(s1.Snapshot().Equals(set.New(1, 2, 3, 4, 5)))
+func (s *ConcurrentSet[T]) Merge(other *ConcurrentSet[T]) {
+	for e := range other.Snapshot().All() {
+		s.Insert(e)
+	}
+}
+
+// Union creates a new set that is the union of the current set and another set.
+//
+// Example:
+//
+//	s1 := sync.New(1, 2, 3)
+//	s2 := sync.New(3, 4, 5)
+//	require.True(s1.Union(s2).Snapshot().Equals(set.New(1, 2, 3, 4, 5)))
+//	require.True(s1.Snapshot().Equals(set.New(1, 2, 3))) // s1 should be unchanged
+func (s *ConcurrentSet[T]) Union(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return s.fromSnapshot(s.Snapshot().Union(other.Snapshot()))
+}
+
+// Intersection creates a new set that is the intersection of the current set and another set.
+//
+// Example:
+//
+//	s1 := sync.New(1, 2, 3, 4)
+//	s2 := sync.New(3, 4, 5, 6)
+//	require.True(s1.Intersection(s2).Snapshot().Equals(set.New(3, 4)))
+func (s *ConcurrentSet[T]) Intersection(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return s.fromSnapshot(s.Snapshot().Intersection(other.Snapshot()))
+}
+
+// Difference creates a new set that contains elements in the current set but not in the other set.
+//
+// Example:
+//
+//	s1 := sync.New(1, 2, 3, 4)
+//	s2 := sync.New(3, 4, 5, 6)
+//	require.True(s1.Difference(s2).Snapshot().Equals(set.New(1, 2)))
+//	require.True(s2.Difference(s1).Snapshot().Equals(set.New(5, 6)))
+func (s *ConcurrentSet[T]) Difference(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return s.fromSnapshot(s.Snapshot().Difference(other.Snapshot()))
+}
+
+// SymmetricDifference creates a new set with elements in either set but not in both.
+//
+// Example:
+//
+//	s1 := sync.New(1, 2, 3, 4)
+//	s2 := sync.New(3, 4, 5, 6)
+//	require.True(s1.SymmetricDifference(s2).Snapshot().Equals(set.New(1, 2, 5, 6)))
+func (s *ConcurrentSet[T]) SymmetricDifference(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return s.fromSnapshot(s.Snapshot().SymmetricDifference(other.Snapshot()))
+}
+
+// fromSnapshot creates a new set with the same shard count as s holding the elements of st.
+func (s *ConcurrentSet[T]) fromSnapshot(st set.Set[T]) *ConcurrentSet[T] {
+	result := NewWithShards[T](len(s.shards))
+	for e := range st.All() {
+		result.Insert(e)
+	}
+	return result
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,313 @@
+package sync_test
+
+import (
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+	"github.com/ysuzuki19/collections-go/set/sync"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	s1 := sync.New[int]()
+	s2 := sync.New(1, 2, 3)
+	require.True(s1.IsEmpty())
+	require.True(s2.Snapshot().Equals(set.New(1, 2, 3)))
+	// testdoc end
+}
+
+func (s *Suite) TestNewWithShards() {
+	require := require.New(s.T())
+	// testdoc begin NewWithShards
+	st := sync.NewWithShards(4, "a", "b")
+	require.True(st.Snapshot().Equals(set.New("a", "b")))
+	// testdoc end
+	for _, shards := range []int{-1, 0, 1, 3, 100} {
+		st := sync.NewWithShards(shards, 1, 2, 3)
+		require.Equal(3, st.Len())
+	}
+}
+
+func (s *Suite) TestFromSet() {
+	require := require.New(s.T())
+	// testdoc begin FromSet
+	st := sync.FromSet(set.New(1, 2, 3))
+	require.True(st.Contains(2))
+	require.Equal(3, st.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestInsert() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Insert
+	st := sync.New[int]()
+	st.Insert(1)
+	st.Insert(2, 3)
+	require.True(st.Snapshot().Equals(set.New(1, 2, 3)))
+	// testdoc end
+}
+
+func (s *Suite) TestInsertIfAbsent() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.InsertIfAbsent
+	st := sync.New(1)
+	require.True(st.InsertIfAbsent(2))
+	require.False(st.InsertIfAbsent(2))
+	require.False(st.InsertIfAbsent(1))
+	// testdoc end
+	require.Equal(2, st.Len())
+}
+
+func (s *Suite) TestLoadOrInsert() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.LoadOrInsert
+	st := sync.New("a")
+	actual, loaded := st.LoadOrInsert("a")
+	require.Equal("a", actual)
+	require.True(loaded)
+	actual, loaded = st.LoadOrInsert("b")
+	require.Equal("b", actual)
+	require.False(loaded)
+	// testdoc end
+	require.True(st.Contains("b"))
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Remove
+	st := sync.New(1, 2, 3, 4)
+	st.Remove(2)
+	st.Remove(1, 3, 5)
+	require.True(st.Snapshot().Equals(set.New(4)))
+	// testdoc end
+}
+
+func (s *Suite) TestRemoveIfPresent() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.RemoveIfPresent
+	st := sync.New(1, 2)
+	require.True(st.RemoveIfPresent(1))
+	require.False(st.RemoveIfPresent(1))
+	// testdoc end
+	require.True(st.Snapshot().Equals(set.New(2)))
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Contains
+	st := sync.New(1, 2, 3)
+	require.True(st.Contains(1))
+	require.False(st.Contains(4))
+	// testdoc end
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Len
+	st := sync.New[int]()
+	require.Equal(0, st.Len())
+	st.Insert(1, 2, 2)
+	require.Equal(2, st.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.IsEmpty
+	st := sync.New[int]()
+	require.True(st.IsEmpty())
+	st.Insert(1)
+	require.False(st.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Equals
+	s1 := sync.New(1, 2, 3)
+	s2 := sync.NewWithShards(2, 3, 2, 1)
+	require.True(s1.Equals(s2))
+	require.False(s1.Equals(sync.New(1, 2)))
+	// testdoc end
+	require.True(s1.Equals(s1))
+}
+
+func (s *Suite) TestSnapshot() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Snapshot
+	st := sync.New(1, 2)
+	snapshot := st.Snapshot()
+	st.Insert(3)
+	require.True(snapshot.Equals(set.New(1, 2))) // snapshot is unaffected by later writes
+	// testdoc end
+	snapshot.Insert(4)
+	require.False(st.Contains(4))
+}
+
+func (s *Suite) TestToSlice() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.ToSlice
+	st := sync.New(1, 2, 3)
+	require.ElementsMatch([]int{1, 2, 3}, st.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.All
+	st := sync.New(1, 2, 3)
+	sum := 0
+	for e := range st.All() {
+		sum += e
+		st.Insert(e * 10) // safe: iteration runs over a snapshot
+	}
+	require.Equal(6, sum)
+	// testdoc end
+	require.Equal(6, st.Len())
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Clear
+	st := sync.New(1, 2)
+	st.Clear()
+	require.True(st.IsEmpty())
+	// testdoc end
+	st.Insert(3)
+	require.Equal(1, st.Len())
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Copy
+	s1 := sync.New(1, 2, 3)
+	s2 := s1.Copy()
+	s1.Insert(4)
+	require.True(s2.Snapshot().Equals(set.New(1, 2, 3)))
+	// testdoc end
+}
+
+func (s *Suite) TestMerge() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Merge
+	s1 := sync.New(1, 2, 3)
+	s2 := sync.New(3, 4, 5)
+	s1.Merge(s2)
+	require.True(s1.Snapshot().Equals(set.New(1, 2, 3, 4, 5)))
+	// testdoc end
+	require.True(s2.Snapshot().Equals(set.New(3, 4, 5)))
+	s1.Merge(s1)
+	require.Equal(5, s1.Len())
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Union
+	s1 := sync.New(1, 2, 3)
+	s2 := sync.New(3, 4, 5)
+	require.True(s1.Union(s2).Snapshot().Equals(set.New(1, 2, 3, 4, 5)))
+	require.True(s1.Snapshot().Equals(set.New(1, 2, 3))) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Intersection
+	s1 := sync.New(1, 2, 3, 4)
+	s2 := sync.New(3, 4, 5, 6)
+	require.True(s1.Intersection(s2).Snapshot().Equals(set.New(3, 4)))
+	// testdoc end
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.Difference
+	s1 := sync.New(1, 2, 3, 4)
+	s2 := sync.New(3, 4, 5, 6)
+	require.True(s1.Difference(s2).Snapshot().Equals(set.New(1, 2)))
+	require.True(s2.Difference(s1).Snapshot().Equals(set.New(5, 6)))
+	// testdoc end
+}
+
+func (s *Suite) TestSymmetricDifference() {
+	require := require.New(s.T())
+	// testdoc begin ConcurrentSet.SymmetricDifference
+	s1 := sync.New(1, 2, 3, 4)
+	s2 := sync.New(3, 4, 5, 6)
+	require.True(s1.SymmetricDifference(s2).Snapshot().Equals(set.New(1, 2, 5, 6)))
+	// testdoc end
+}
+
+func (s *Suite) TestConcurrentInsertIfAbsent() {
+	require := require.New(s.T())
+
+	const workers, perWorker = 8, 500
+	st := sync.New[int]()
+	var inserted atomic.Int64
+	var wg stdsync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				if st.InsertIfAbsent(i) {
+					inserted.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// every element is reported as new exactly once
+	require.Equal(int64(perWorker), inserted.Load())
+	require.Equal(perWorker, st.Len())
+}
+
+func (s *Suite) TestConcurrentMixed() {
+	require := require.New(s.T())
+
+	const workers, perWorker = 8, 300
+	st := sync.New[int]()
+	other := sync.New(1, 2, 3)
+	var wg stdsync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				e := w*perWorker + i
+				st.Insert(e)
+				_ = st.Contains(e)
+				_ = st.Len()
+				if i%10 == 0 {
+					_ = st.Snapshot()
+					_ = st.Union(other)
+					other.Merge(st.Intersection(other))
+				}
+				if i%2 == 0 {
+					st.Remove(e)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(workers*perWorker/2, st.Len())
+	for e := range st.All() {
+		require.Equal(1, e%2)
+	}
+}
@@ -0,0 +1,524 @@
+// Package sortedset provides a set that keeps its elements in sorted order.
+package sortedset
+
+import (
+	"cmp"
+	"iter"
+)
+
+// SortedSet is a generic set that keeps its elements ordered.
+// It is backed by an AVL tree augmented with subtree sizes,
+// so lookups, updates, rank and select queries run in O(log n).
+//
+// A SortedSet must be created with New or NewFunc.
+type SortedSet[T any] struct {
+	root *node[T]
+	cmp  func(a, b T) int
+}
+
+// New creates a new SortedSet ordered by cmp.Compare and initializes it with the provided elements.
+//
+// Example:
+//
+//	s1 := sortedset.New[int]()
+//	s2 := sortedset.New(3, 1, 2)
+//	require.True(s1.IsEmpty())
+//	require.Equal([]int{1, 2, 3}, s2.ToSlice())
+func New[T cmp.Ordered](elements ...T) *SortedSet[T] {
+	return NewFunc(cmp.Compare[T], elements...)
+}
+
+// NewFunc creates a new SortedSet ordered by the given comparison function
+// and initializes it with the provided elements.
+// cmp follows the convention of slices.SortFunc; elements comparing equal are treated as the same element.
+//
+// Example:
+//
+//	byLength := func(a, b string) int { return len(a) - len(b) }
+//	st := sortedset.NewFunc(byLength, "ccc", "a", "bb")
+//	require.Equal([]string{"a", "bb", "ccc"}, st.ToSlice())
+func NewFunc[T any](cmp func(a, b T) int, elements ...T) *SortedSet[T] {
+	s := &SortedSet[T]{cmp: cmp}
+	s.Insert(elements...)
+	return s
+}
+
+// fromSorted creates a new SortedSet sharing the ordering of s from strictly increasing values.
+func (s *SortedSet[T]) fromSorted(values []T) *SortedSet[T] {
+	return &SortedSet[T]{root: build(values), cmp: s.cmp}
+}
+
+// Insert adds one or more elements to the set.
+//
+// Example:
+//
+//	st := sortedset.New[int]()
+//	st.Insert(3)
+//	st.Insert(1, 2, 3)
+//	require.Equal([]int{1, 2, 3}, st.ToSlice())
+func (s *SortedSet[T]) Insert(elements ...T) {
+	for _, e := range elements {
+		s.root, _ = insert(s.root, e, s.cmp)
+	}
+}
+
+// Remove deletes one or more elements from the set.
+//
+// Example:
+//
+//	st := sortedset.New(1, 2, 3, 4)
+//	st.Remove(2)
+//	st.Remove(1, 5)
+//	require.Equal([]int{3, 4}, st.ToSlice())
+func (s *SortedSet[T]) Remove(elements ...T) {
+	for _, e := range elements {
+		s.root, _ = remove(s.root, e, s.cmp)
+	}
+}
+
+// Contains checks if the set contains a specific element.
+//
+// Example:
+//
+//	st := sortedset.New(1, 2, 3)
+//	require.True(st.Contains(2))
+//	require.False(st.Contains(4))
+func (s *SortedSet[T]) Contains(element T) bool {
+	n := s.root
+	for n != nil {
+		switch c := s.cmp(element, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in the set.
+//
+// Example:
+//
+//	st := sortedset.New[int]()
+//	require.Equal(0, st.Len())
+//	st.Insert(1, 1, 2)
+//	require.Equal(2, st.Len())
+func (s *SortedSet[T]) Len() int {
+	return sizeOf(s.root)
+}
+
+// IsEmpty checks if the set is empty.
+//
+// Example:
+//
+//	st := sortedset.New[int]()
+//	require.True(st.IsEmpty())
+//	st.Insert(1)
+//	require.False(st.IsEmpty())
+func (s *SortedSet[T]) IsEmpty() bool {
+	return s.root == nil
+}
+
+// Equals checks if two sets contain the same elements.
+//
+// Example:
+//
+//	s1 := sortedset.New(1, 2, 3)
+//	require.True(s1.Equals(sortedset.New(3, 2, 1)))
+//	require.False(s1.Equals(sortedset.New(1, 2)))
+//	require.False(s1.Equals(sortedset.New(1, 2, 4)))
+func (s *SortedSet[T]) Equals(other *SortedSet[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	next, stop := iter.Pull(other.All())
+	defer stop()
+	for e := range s.All() {
+		o, _ := next()
+		if s.cmp(e, o) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ToSlice returns the elements of the set in ascending order.
+//
+// Example:
+//
+//	st := sortedset.New(5, 3, 9, 1)
+//	require.Equal([]int{1, 3, 5, 9}, st.ToSlice())
+func (s *SortedSet[T]) ToSlice() []T {
+	elements := make([]T, 0, s.Len())
+	for e := range s.All() {
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+// Clear removes all elements from the set.
+//
+// Example:
+//
+//	st := sortedset.New(1, 2)
+//	st.Clear()
+//	require.True(st.IsEmpty())
+func (s *SortedSet[T]) Clear() {
+	s.root = nil
+}
+
+// Copy creates a shallow copy of the set in O(n).
+//
+// Example:
+//
+//	s1 := sortedset.New(1, 2, 3)
+//	s2 := s1.Copy()
+//	s1.Insert(4)
+//	require.Equal([]int{1, 2, 3}, s2.ToSlice())
+func (s *SortedSet[T]) Copy() *SortedSet[T] {
+	return s.fromSorted(s.ToSlice())
+}
+
+// Merge adds all elements from another set into the current set.
+//
+// Example:
+//
+//	s1 := sortedset.New(1, 2, 3)
+//	s1.Merge(sortedset.New(3, 4, 5))
+//	require.Equal([]int{1, 2, 3, 4, 5}, s1.ToSlice())
+func (s *SortedSet[T]) Merge(other *SortedSet[T]) {
+	s.root = s.Union(other).root
+}
+
+// Min returns the smallest element of the set.
+// The second result is false if the set is empty.
+//
+// Example:
+//
+//	st := sortedset.New(3, 1, 2)
+//	v, ok := st.Min()
+//	require.True(ok)
+//	require.Equal(1, v)
+func (s *SortedSet[T]) Min() (T, bool) {
+	if s.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := s.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.value, true
+}
+
+// Max returns the largest element of the set.
+// The second result is false if the set is empty.
+//
+// Example:
+//
+//	st := sortedset.New(3, 1, 2)
+//	v, ok := st.Max()
+//	require.True(ok)
+//	require.Equal(3, v)
+func (s *SortedSet[T]) Max() (T, bool) {
+	if s.root == nil {
+		var zero T
+		return zero, false
+	}
+	n := s.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value, true
+}
+
+// Floor returns the largest element less than or equal to x.
+// The second result is false if there is no such element.
+//
+// Example:
+//
+//	st := sortedset.New(10, 20, 30)
+//	v, ok := st.Floor(25)
+//	require.True(ok)
+//	require.Equal(20, v)
+//	v, ok = st.Floor(20)
+//	require.True(ok)
+//	require.Equal(20, v)
+//	_, ok = st.Floor(5)
+//	require.False(ok)
+func (s *SortedSet[T]) Floor(x T) (T, bool) {
+	var floor T
+	found := false
+	n := s.root
+	for n != nil {
+		switch c := s.cmp(x, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			floor, found = n.value, true
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return floor, found
+}
+
+// Ceiling returns the smallest element greater than or equal to x.
+// The second result is false if there is no such element.
+//
+// Example:
+//
+//	st := sortedset.New(10, 20, 30)
+//	v, ok := st.Ceiling(15)
+//	require.True(ok)
+//	require.Equal(20, v)
+//	v, ok = st.Ceiling(20)
+//	require.True(ok)
+//	require.Equal(20, v)
+//	_, ok = st.Ceiling(35)
+//	require.False(ok)
+func (s *SortedSet[T]) Ceiling(x T) (T, bool) {
+	var ceiling T
+	found := false
+	n := s.root
+	for n != nil {
+		switch c := s.cmp(x, n.value); {
+		case c < 0:
+			ceiling, found = n.value, true
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return ceiling, found
+}
+
+// Rank returns the number of elements strictly less than x.
+// If x is in the set, it is the zero-based index of x in ascending order.
+//
+// Example:
+//
+//	st := sortedset.New(10, 20, 30)
+//	require.Equal(0, st.Rank(10))
+//	require.Equal(1, st.Rank(15))
+//	require.Equal(2, st.Rank(30))
+//	require.Equal(3, st.Rank(99))
+func (s *SortedSet[T]) Rank(x T) int {
+	rank := 0
+	n := s.root
+	for n != nil {
+		if s.cmp(x, n.value) <= 0 {
+			n = n.left
+		} else {
+			rank += sizeOf(n.left) + 1
+			n = n.right
+		}
+	}
+	return rank
+}
+
+// Select returns the element at zero-based index i in ascending order.
+// The second result is false if i is out of range.
+//
+// Example:
+//
+//	st := sortedset.New(30, 10, 20)
+//	v, ok := st.Select(1)
+//	require.True(ok)
+//	require.Equal(20, v)
+//	_, ok = st.Select(3)
+//	require.False(ok)
+func (s *SortedSet[T]) Select(i int) (T, bool) {
+	if i < 0 || i >= s.Len() {
+		var zero T
+		return zero, false
+	}
+	n := s.root
+	for {
+		switch left := sizeOf(n.left); {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+}
+
+// All returns an iterator over the elements of the set in ascending order.
+//
+// Example:
+//
+//	st := sortedset.New(3, 1, 2)
+//	var got []int
+//	for e := range st.All() {
+//		got = append(got, e)
+//	}
+//	require.Equal([]int{1, 2, 3}, got)
+func (s *SortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack []*node[T]
+		for n := s.root; n != nil; n = n.left {
+			stack = append(stack, n)
+		}
+		ascend(stack, func(T) bool { return true }, yield)
+	}
+}
+
+// Backward returns an iterator over the elements of the set in descending order.
+//
+// Example:
+//
+//	st := sortedset.New(3, 1, 2)
+//	require.Equal([]int{3, 2, 1}, slices.Collect(st.Backward()))
+func (s *SortedSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack []*node[T]
+		for n := s.root; n != nil; n = n.right {
+			stack = append(stack, n)
+		}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.value) {
+				return
+			}
+			for c := n.left; c != nil; c = c.right {
+				stack = append(stack, c)
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the elements e with lo <= e < hi in ascending order.
+//
+// Example:
+//
+//	st := sortedset.New(1, 3, 5, 7, 9)
+//	require.Equal([]int{3, 5, 7}, slices.Collect(st.Range(2, 9)))
+func (s *SortedSet[T]) Range(lo, hi T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// Seek the path to the first element not less than lo.
+		var stack []*node[T]
+		n := s.root
+		for n != nil {
+			if s.cmp(n.value, lo) >= 0 {
+				stack = append(stack, n)
+				n = n.left
+			} else {
+				n = n.right
+			}
+		}
+		ascend(stack, func(e T) bool { return s.cmp(e, hi) < 0 }, yield)
+	}
+}
+
+// ascend performs an in-order traversal from the given stack of pending ancestors,
+// stopping at the first element for which inRange returns false.
+func ascend[T any](stack []*node[T], inRange func(T) bool, yield func(T) bool) {
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !inRange(n.value) || !yield(n.value) {
+			return
+		}
+		for c := n.right; c != nil; c = c.left {
+			stack = append(stack, c)
+		}
+	}
+}
+
+// Union creates a new set that is the union of the current set and another set.
+// Both sets are merged linearly in O(n + m); the other set must use the same ordering.
+//
+// Example:
+//
+//	s1 := sortedset.New(1, 2, 3)
+//	s2 := sortedset.New(3, 4, 5)
+//	require.Equal([]int{1, 2, 3, 4, 5}, s1.Union(s2).ToSlice())
+//	require.Equal([]int{1, 2, 3}, s1.ToSlice()) // s1 should be unchanged
+func (s *SortedSet[T]) Union(other *SortedSet[T]) *SortedSet[T] {
+	return s.fromSorted(s.merge(other, true, true, true))
+}
+
+// Intersection creates a new set that is the intersection of the current set and another set.
+// Both sets are merged linearly in O(n + m); the other set must use the same ordering.
+//
+// Example:
+//
+//	s1 := sortedset.New(1, 2, 3, 4)
+//	s2 := sortedset.New(3, 4, 5, 6)
+//	require.Equal([]int{3, 4}, s1.Intersection(s2).ToSlice())
+func (s *SortedSet[T]) Intersection(other *SortedSet[T]) *SortedSet[T] {
+	return s.fromSorted(s.merge(other, false, true, false))
+}
+
+// Difference creates a new set that contains elements in the current set but not in the other set.
+// Both sets are merged linearly in O(n + m); the other set must use the same ordering.
+//
+// Example:
+//
+//	s1 := sortedset.New(1, 2, 3, 4)
+//	s2 := sortedset.New(3, 4, 5, 6)
+//	require.Equal([]int{1, 2}, s1.Difference(s2).ToSlice())
+//	require.Equal([]int{5, 6}, s2.Difference(s1).ToSlice())
+func (s *SortedSet[T]) Difference(other *SortedSet[T]) *SortedSet[T] {
+	return s.fromSorted(s.merge(other, true, false, false))
+}
+
+// SymmetricDifference creates a new set with elements in either set but not in both.
+// Both sets are merged linearly in O(n + m); the other set must use the same ordering.
+//
+// Example:
+//
+//	s1 := sortedset.New(1, 2, 3, 4)
+//	s2 := sortedset.New(3, 4, 5, 6)
+//	require.Equal([]int{1, 2, 5, 6}, s1.SymmetricDifference(s2).ToSlice())
+func (s *SortedSet[T]) SymmetricDifference(other *SortedSet[T]) *SortedSet[T] {
+	return s.fromSorted(s.merge(other, true, false, true))
+}
+
+// merge walks both sets in ascending order and collects the elements found
+// only in s, in both sets, and only in other, as selected by the flags.
+func (s *SortedSet[T]) merge(other *SortedSet[T], onlyLeft, both, onlyRight bool) []T {
+	a, b := s.ToSlice(), other.ToSlice()
+	result := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := s.cmp(a[i], b[j]); {
+		case c < 0:
+			if onlyLeft {
+				result = append(result, a[i])
+			}
+			i++
+		case c > 0:
+			if onlyRight {
+				result = append(result, b[j])
+			}
+			j++
+		default:
+			if both {
+				result = append(result, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if onlyLeft {
+		result = append(result, a[i:]...)
+	}
+	if onlyRight {
+		result = append(result, b[j:]...)
+	}
+	return result
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
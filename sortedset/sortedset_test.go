@@ -0,0 +1,361 @@
+package sortedset_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/set"
+	"github.com/ysuzuki19/collections-go/sortedset"
+)
+
+type Suite struct {
+	suite.Suite
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+func (s *Suite) TestNew() {
+	require := require.New(s.T())
+	// testdoc begin New
+	s1 := sortedset.New[int]()
+	s2 := sortedset.New(3, 1, 2)
+	require.True(s1.IsEmpty())
+	require.Equal([]int{1, 2, 3}, s2.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestNewFunc() {
+	require := require.New(s.T())
+	// testdoc begin NewFunc
+	byLength := func(a, b string) int { return len(a) - len(b) }
+	st := sortedset.NewFunc(byLength, "ccc", "a", "bb")
+	require.Equal([]string{"a", "bb", "ccc"}, st.ToSlice())
+	// testdoc end
+
+	// elements comparing equal are the same element
+	folded := sortedset.NewFunc(func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}, "Go", "go", "GO")
+	require.Equal(1, folded.Len())
+}
+
+func (s *Suite) TestInsert() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Insert
+	st := sortedset.New[int]()
+	st.Insert(3)
+	st.Insert(1, 2, 3)
+	require.Equal([]int{1, 2, 3}, st.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestRemove() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Remove
+	st := sortedset.New(1, 2, 3, 4)
+	st.Remove(2)
+	st.Remove(1, 5)
+	require.Equal([]int{3, 4}, st.ToSlice())
+	// testdoc end
+	st.Remove(3, 4)
+	require.True(st.IsEmpty())
+}
+
+func (s *Suite) TestContains() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Contains
+	st := sortedset.New(1, 2, 3)
+	require.True(st.Contains(2))
+	require.False(st.Contains(4))
+	// testdoc end
+	require.False(sortedset.New[int]().Contains(0))
+}
+
+func (s *Suite) TestLen() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Len
+	st := sortedset.New[int]()
+	require.Equal(0, st.Len())
+	st.Insert(1, 1, 2)
+	require.Equal(2, st.Len())
+	// testdoc end
+}
+
+func (s *Suite) TestIsEmpty() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.IsEmpty
+	st := sortedset.New[int]()
+	require.True(st.IsEmpty())
+	st.Insert(1)
+	require.False(st.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestEquals() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Equals
+	s1 := sortedset.New(1, 2, 3)
+	require.True(s1.Equals(sortedset.New(3, 2, 1)))
+	require.False(s1.Equals(sortedset.New(1, 2)))
+	require.False(s1.Equals(sortedset.New(1, 2, 4)))
+	// testdoc end
+	require.True(sortedset.New[int]().Equals(sortedset.New[int]()))
+}
+
+func (s *Suite) TestToSlice() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.ToSlice
+	st := sortedset.New(5, 3, 9, 1)
+	require.Equal([]int{1, 3, 5, 9}, st.ToSlice())
+	// testdoc end
+	require.Empty(sortedset.New[int]().ToSlice())
+}
+
+func (s *Suite) TestClear() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Clear
+	st := sortedset.New(1, 2)
+	st.Clear()
+	require.True(st.IsEmpty())
+	// testdoc end
+}
+
+func (s *Suite) TestCopy() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Copy
+	s1 := sortedset.New(1, 2, 3)
+	s2 := s1.Copy()
+	s1.Insert(4)
+	require.Equal([]int{1, 2, 3}, s2.ToSlice())
+	// testdoc end
+	s2.Remove(1)
+	require.Equal([]int{1, 2, 3, 4}, s1.ToSlice())
+}
+
+func (s *Suite) TestMerge() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Merge
+	s1 := sortedset.New(1, 2, 3)
+	s1.Merge(sortedset.New(3, 4, 5))
+	require.Equal([]int{1, 2, 3, 4, 5}, s1.ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestMin() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Min
+	st := sortedset.New(3, 1, 2)
+	v, ok := st.Min()
+	require.True(ok)
+	require.Equal(1, v)
+	// testdoc end
+	_, ok = sortedset.New[int]().Min()
+	require.False(ok)
+}
+
+func (s *Suite) TestMax() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Max
+	st := sortedset.New(3, 1, 2)
+	v, ok := st.Max()
+	require.True(ok)
+	require.Equal(3, v)
+	// testdoc end
+	_, ok = sortedset.New[int]().Max()
+	require.False(ok)
+}
+
+func (s *Suite) TestFloor() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Floor
+	st := sortedset.New(10, 20, 30)
+	v, ok := st.Floor(25)
+	require.True(ok)
+	require.Equal(20, v)
+	v, ok = st.Floor(20)
+	require.True(ok)
+	require.Equal(20, v)
+	_, ok = st.Floor(5)
+	require.False(ok)
+	// testdoc end
+	v, ok = st.Floor(100)
+	require.True(ok)
+	require.Equal(30, v)
+}
+
+func (s *Suite) TestCeiling() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Ceiling
+	st := sortedset.New(10, 20, 30)
+	v, ok := st.Ceiling(15)
+	require.True(ok)
+	require.Equal(20, v)
+	v, ok = st.Ceiling(20)
+	require.True(ok)
+	require.Equal(20, v)
+	_, ok = st.Ceiling(35)
+	require.False(ok)
+	// testdoc end
+	v, ok = st.Ceiling(-1)
+	require.True(ok)
+	require.Equal(10, v)
+}
+
+func (s *Suite) TestRank() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Rank
+	st := sortedset.New(10, 20, 30)
+	require.Equal(0, st.Rank(10))
+	require.Equal(1, st.Rank(15))
+	require.Equal(2, st.Rank(30))
+	require.Equal(3, st.Rank(99))
+	// testdoc end
+}
+
+func (s *Suite) TestSelect() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Select
+	st := sortedset.New(30, 10, 20)
+	v, ok := st.Select(1)
+	require.True(ok)
+	require.Equal(20, v)
+	_, ok = st.Select(3)
+	require.False(ok)
+	// testdoc end
+	_, ok = st.Select(-1)
+	require.False(ok)
+}
+
+func (s *Suite) TestAll() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.All
+	st := sortedset.New(3, 1, 2)
+	var got []int
+	for e := range st.All() {
+		got = append(got, e)
+	}
+	require.Equal([]int{1, 2, 3}, got)
+	// testdoc end
+
+	got = nil
+	for e := range st.All() {
+		got = append(got, e)
+		break
+	}
+	require.Equal([]int{1}, got)
+}
+
+func (s *Suite) TestBackward() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Backward
+	st := sortedset.New(3, 1, 2)
+	require.Equal([]int{3, 2, 1}, slices.Collect(st.Backward()))
+	// testdoc end
+
+	var got []int
+	for e := range st.Backward() {
+		got = append(got, e)
+		break
+	}
+	require.Equal([]int{3}, got)
+}
+
+func (s *Suite) TestRange() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Range
+	st := sortedset.New(1, 3, 5, 7, 9)
+	require.Equal([]int{3, 5, 7}, slices.Collect(st.Range(2, 9)))
+	// testdoc end
+	require.Equal([]int{1, 3}, slices.Collect(st.Range(1, 5)))
+	require.Empty(slices.Collect(st.Range(10, 20)))
+	require.Empty(slices.Collect(st.Range(5, 5)))
+	require.Equal([]int{1, 3, 5, 7, 9}, slices.Collect(st.Range(-100, 100)))
+
+	var got []int
+	for e := range st.Range(0, 100) {
+		got = append(got, e)
+		if len(got) == 2 {
+			break
+		}
+	}
+	require.Equal([]int{1, 3}, got)
+}
+
+func (s *Suite) TestUnion() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Union
+	s1 := sortedset.New(1, 2, 3)
+	s2 := sortedset.New(3, 4, 5)
+	require.Equal([]int{1, 2, 3, 4, 5}, s1.Union(s2).ToSlice())
+	require.Equal([]int{1, 2, 3}, s1.ToSlice()) // s1 should be unchanged
+	// testdoc end
+}
+
+func (s *Suite) TestIntersection() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Intersection
+	s1 := sortedset.New(1, 2, 3, 4)
+	s2 := sortedset.New(3, 4, 5, 6)
+	require.Equal([]int{3, 4}, s1.Intersection(s2).ToSlice())
+	// testdoc end
+	require.True(s1.Intersection(sortedset.New[int]()).IsEmpty())
+}
+
+func (s *Suite) TestDifference() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.Difference
+	s1 := sortedset.New(1, 2, 3, 4)
+	s2 := sortedset.New(3, 4, 5, 6)
+	require.Equal([]int{1, 2}, s1.Difference(s2).ToSlice())
+	require.Equal([]int{5, 6}, s2.Difference(s1).ToSlice())
+	// testdoc end
+}
+
+func (s *Suite) TestSymmetricDifference() {
+	require := require.New(s.T())
+	// testdoc begin SortedSet.SymmetricDifference
+	s1 := sortedset.New(1, 2, 3, 4)
+	s2 := sortedset.New(3, 4, 5, 6)
+	require.Equal([]int{1, 2, 5, 6}, s1.SymmetricDifference(s2).ToSlice())
+	// testdoc end
+}
+
+// TestRandomized checks the tree against set.Set under random inserts and removals.
+func (s *Suite) TestRandomized() {
+	require := require.New(s.T())
+
+	r := rand.New(rand.NewPCG(1, 2))
+	st := sortedset.New[int]()
+	ref := set.New[int]()
+	for range 5000 {
+		e := r.IntN(500)
+		if r.IntN(3) == 0 {
+			st.Remove(e)
+			ref.Remove(e)
+		} else {
+			st.Insert(e)
+			ref.Insert(e)
+		}
+	}
+
+	expected := ref.ToSlice()
+	slices.Sort(expected)
+	require.Equal(expected, st.ToSlice())
+	require.Equal(len(expected), st.Len())
+	for i, e := range expected {
+		require.Equal(i, st.Rank(e))
+		v, ok := st.Select(i)
+		require.True(ok)
+		require.Equal(e, v)
+	}
+	for x := -1; x <= 501; x++ {
+		require.Equal(ref.Contains(x), st.Contains(x))
+	}
+}
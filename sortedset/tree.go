@@ -0,0 +1,143 @@
+package sortedset
+
+// node is a node of an AVL tree augmented with subtree sizes for rank and select queries.
+type node[T any] struct {
+	value  T
+	left   *node[T]
+	right  *node[T]
+	height int
+	size   int
+}
+
+func newNode[T any](value T) *node[T] {
+	return &node[T]{value: value, height: 1, size: 1}
+}
+
+func heightOf[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func sizeOf[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node[T]) update() {
+	n.height = 1 + max(heightOf(n.left), heightOf(n.right))
+	n.size = 1 + sizeOf(n.left) + sizeOf(n.right)
+}
+
+func rotateRight[T any](n *node[T]) *node[T] {
+	l := n.left
+	n.left = l.right
+	n.update()
+	l.right = n
+	l.update()
+	return l
+}
+
+func rotateLeft[T any](n *node[T]) *node[T] {
+	r := n.right
+	n.right = r.left
+	n.update()
+	r.left = n
+	r.update()
+	return r
+}
+
+// rebalance restores the AVL invariant at n and returns the new subtree root.
+func rebalance[T any](n *node[T]) *node[T] {
+	n.update()
+	switch balance := heightOf(n.left) - heightOf(n.right); {
+	case balance > 1:
+		if heightOf(n.left.left) < heightOf(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case balance < -1:
+		if heightOf(n.right.right) < heightOf(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	default:
+		return n
+	}
+}
+
+// insert adds value to the subtree rooted at n and reports whether it was newly inserted.
+func insert[T any](n *node[T], value T, cmp func(a, b T) int) (*node[T], bool) {
+	if n == nil {
+		return newNode(value), true
+	}
+	var inserted bool
+	switch c := cmp(value, n.value); {
+	case c < 0:
+		n.left, inserted = insert(n.left, value, cmp)
+	case c > 0:
+		n.right, inserted = insert(n.right, value, cmp)
+	default:
+		return n, false
+	}
+	if !inserted {
+		return n, false
+	}
+	return rebalance(n), true
+}
+
+// remove deletes value from the subtree rooted at n and reports whether it was present.
+func remove[T any](n *node[T], value T, cmp func(a, b T) int) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := cmp(value, n.value); {
+	case c < 0:
+		n.left, removed = remove(n.left, value, cmp)
+	case c > 0:
+		n.right, removed = remove(n.right, value, cmp)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.value = successor.value
+		n.right = removeMin(n.right)
+		removed = true
+	}
+	if !removed {
+		return n, false
+	}
+	return rebalance(n), true
+}
+
+func removeMin[T any](n *node[T]) *node[T] {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = removeMin(n.left)
+	return rebalance(n)
+}
+
+// build creates a perfectly balanced tree from strictly increasing values in O(n).
+func build[T any](values []T) *node[T] {
+	if len(values) == 0 {
+		return nil
+	}
+	mid := len(values) / 2
+	n := newNode(values[mid])
+	n.left = build(values[:mid])
+	n.right = build(values[mid+1:])
+	n.update()
+	return n
+}
@@ -1,13 +1,9 @@
 package traceback
 
-import (
-	"errors"
-
-	"github.com/ysuzuki19/collections-go/traceback/internal/frame"
-)
+import "errors"
 
 // FramesOf extracts the stack frames from the given error.
-// If the error is not a traceback.Error, it returns an empty slice.
+// If the error is not a traceback.Error, it returns empty Frames.
 //
 // Example:
 //
@@ -21,12 +17,12 @@ import (
 //	_ = frames.Format(func(args traceback.FormatterArgs) string {
 //		return fmt.Sprintf("%s:%d (%s)", args.File, args.Line, args.Function)
 //	})
-func FramesOf(err error) frame.Frames {
+func FramesOf(err error) Frames {
 	var te *Error
 	if errors.As(err, &te) {
-		return te.frames
+		return te.Frames()
 	}
-	return frame.Frames{} // fallback to empty frames
+	return Frames{} // fallback to empty frames
 }
 
 //go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
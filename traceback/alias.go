@@ -1,6 +1,9 @@
 package traceback
 
-import "github.com/ysuzuki19/collections-go/traceback/internal/frame"
+// Formatter renders a single frame for Frames.Format and Frame.Format.
+// It is an alias, as it was before Frame became a public type, so existing formatters keep compiling.
+type Formatter = func(Frame) string
 
-type Formatter = frame.Formatter
-type FormatterArgs = frame.Frame
+// FormatterArgs is the frame passed to a Formatter.
+// It is kept for compatibility; new code can use Frame.
+type FormatterArgs = Frame
@@ -0,0 +1,65 @@
+package traceback
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Layer is one traced error in a cause chain.
+type Layer struct {
+	// Message is the error message of the layer, including the messages of its causes.
+	Message string
+	// Frames is the stack trace captured for the layer.
+	Frames Frames
+}
+
+// Chain returns every traced error in the cause chain of err, from the outermost to the root.
+// Errors without a trace, such as fmt.Errorf wrappers, are skipped.
+// For causes joined with errors.Join, the first traced error found depth-first is followed.
+// Returns nil if err has no trace.
+//
+// Example:
+//
+//	root := traceback.New("disk full")
+//	err := traceback.Wrap(root, "failed to save")
+//	layers := traceback.Chain(fmt.Errorf("request failed: %w", err))
+//	s.Len(layers, 2)
+//	s.Equal("failed to save: disk full", layers[0].Message)
+//	s.Equal("disk full", layers[1].Message)
+//	s.Equal(root.Frames(), layers[1].Frames)
+func Chain(err error) []Layer {
+	var layers []Layer
+	var te *Error
+	for errors.As(err, &te) {
+		layers = append(layers, Layer{Message: te.Error(), Frames: te.Frames()})
+		err = te.cause
+	}
+	return layers
+}
+
+// renderChain renders the layers in the style of Java stack traces:
+// each cause follows a "Caused by:" line, and frames shared with the enclosing layer
+// are elided as "... N more". Stacks cut off at the capture depth end with "... truncated".
+func renderChain(layers []Layer) string {
+	var sb strings.Builder
+	for i, layer := range layers {
+		frames, shared := layer.Frames.frames, 0
+		if i > 0 {
+			sb.WriteString("Caused by: ")
+			shared = frames.CommonSuffixLen(layers[i-1].Frames.frames)
+			frames = frames.TrimSuffix(shared)
+		}
+		sb.WriteString(layer.Message)
+		sb.WriteString("\n")
+		sb.WriteString(frames.String())
+		if shared > 0 {
+			fmt.Fprintf(&sb, "\t... %d more\n", shared)
+		} else if layer.Frames.Truncated() {
+			sb.WriteString("\t... truncated\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,84 @@
+package traceback_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/traceback"
+)
+
+type ChainSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func (s *ChainSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func TestChainSuite(t *testing.T) {
+	suite.Run(t, new(ChainSuite))
+}
+
+func (s *ChainSuite) TestChain() {
+	// testdoc begin Chain
+	root := traceback.New("disk full")
+	err := traceback.Wrap(root, "failed to save")
+	layers := traceback.Chain(fmt.Errorf("request failed: %w", err))
+	s.Len(layers, 2)
+	s.Equal("failed to save: disk full", layers[0].Message)
+	s.Equal("disk full", layers[1].Message)
+	s.Equal(root.Frames(), layers[1].Frames)
+	// testdoc end
+
+	s.Equal(err.Frames(), layers[0].Frames)
+}
+
+func (s *ChainSuite) TestChain_NoTrace() {
+	s.Nil(traceback.Chain(nil))
+	s.Nil(traceback.Chain(io.EOF))
+	s.Nil(traceback.Chain(fmt.Errorf("wrapped: %w", io.EOF)))
+}
+
+func (s *ChainSuite) TestChain_Layers() {
+	err := traceback.From(io.EOF)
+	err = traceback.Wrap(fmt.Errorf("read: %w", err), "parse")
+	err = traceback.Wrapf(err, "load %s", "config")
+
+	layers := traceback.Chain(err)
+	s.Len(layers, 3)
+	s.Equal("load config: parse: read: EOF", layers[0].Message)
+	s.Equal("parse: read: EOF", layers[1].Message)
+	s.Equal("EOF", layers[2].Message)
+
+	joined := traceback.Wrap(errors.Join(io.EOF, traceback.New("second")), "joined")
+	layers = traceback.Chain(joined)
+	s.Len(layers, 2)
+	s.Equal("second", layers[1].Message)
+}
+
+func (s *ChainSuite) TestRender() {
+	save := func() error {
+		return traceback.New("disk full")
+	}
+	cause := save()
+	err := traceback.Wrap(cause, "failed to save")
+
+	trace := fmt.Sprintf("%+v", err)
+	sections := strings.Split(trace, "\nCaused by: ")
+	s.Len(sections, 2)
+
+	// the cause keeps only its own frames and the frame it was called from
+	lines := strings.Split(sections[1], "\n")
+	s.Equal("disk full", lines[0])
+	s.Contains(lines[1], "TestRender.func1()")
+	s.Contains(lines[3], "(*ChainSuite).TestRender()")
+	s.Equal(fmt.Sprintf("\t... %d more", err.Frames().Len()-1), lines[len(lines)-1])
+	s.Len(lines, 1+2*2+1)
+}
@@ -16,19 +16,21 @@ type Error struct {
 var _ error = (*Error)(nil)
 
 // New creates a new Error with the given message.
+// See Depth for limiting the recorded stack.
 //
 // Example:
 //
 //	err := traceback.New("something went wrong")
 //	fmt.Println(err.Error())
-func New(message string) *Error {
+func New(message string, opts ...Option) *Error {
 	return &Error{
 		cause:  errors.New(message),
-		frames: frame.Capture(2),
+		frames: newOptions(opts).capture(nil, 1),
 	}
 }
 
 // Errorf creates a new Error with a formatted message.
+// Use ErrorfWith to pass options.
 //
 // Example:
 //
@@ -37,12 +39,26 @@ func New(message string) *Error {
 func Errorf(format string, args ...any) *Error {
 	return &Error{
 		cause:  fmt.Errorf(format, args...),
-		frames: frame.Capture(2),
+		frames: newOptions(nil).capture(nil, 1),
+	}
+}
+
+// ErrorfWith is Errorf with options, such as Depth, which cannot follow the format arguments.
+//
+// Example:
+//
+//	err := traceback.ErrorfWith([]traceback.Option{traceback.Depth(1)}, "failed to process item %d", 42)
+//	s.Equal("failed to process item 42", err.Error())
+//	s.Equal(1, err.Frames().Len())
+func ErrorfWith(opts []Option, format string, args ...any) *Error {
+	return &Error{
+		cause:  fmt.Errorf(format, args...),
+		frames: newOptions(opts).capture(nil, 1),
 	}
 }
 
 // From wraps an existing error with a Error, adding stack trace information.
-// Returns nil if err is nil.
+// Returns nil if err is nil. See ReuseTrace and Depth for controlling the recorded stack.
 //
 // Example:
 //
@@ -51,18 +67,18 @@ func Errorf(format string, args ...any) *Error {
 //	if err != nil {
 //		err = traceback.From(err)
 //	}
-func From(err error) *Error {
+func From(err error, opts ...Option) *Error {
 	if err == nil {
 		return nil
 	}
 	return &Error{
 		cause:  err,
-		frames: frame.Capture(2),
+		frames: newOptions(opts).capture(err, 1),
 	}
 }
 
 // Wrap wraps an existing error with a Error and additional context message.
-// Returns nil if err is nil.
+// Returns nil if err is nil. See ReuseTrace and Depth for controlling the recorded stack.
 //
 // Example:
 //
@@ -71,18 +87,18 @@ func From(err error) *Error {
 //	if err != nil {
 //		err = traceback.Wrap(err, "failed to complete operation")
 //	}
-func Wrap(err error, message string) *Error {
+func Wrap(err error, message string, opts ...Option) *Error {
 	if err == nil {
 		return nil
 	}
 	return &Error{
 		cause:  fmt.Errorf("%s: %w", message, err),
-		frames: frame.Capture(2),
+		frames: newOptions(opts).capture(err, 1),
 	}
 }
 
 // Wrapf wraps an existing error with a formatted context message.
-// Returns nil if err is nil.
+// Returns nil if err is nil. Use WrapfWith to pass options.
 //
 // Example:
 //
@@ -101,7 +117,27 @@ func Wrapf(err error, format string, args ...any) *Error {
 	msg := fmt.Sprintf(format, args...)
 	return &Error{
 		cause:  fmt.Errorf("%s: %w", msg, err),
-		frames: frame.Capture(2),
+		frames: newOptions(nil).capture(err, 1),
+	}
+}
+
+// WrapfWith is Wrapf with options, such as ReuseTrace and Depth, which cannot follow the format arguments.
+// Returns nil if err is nil.
+//
+// Example:
+//
+//	inner := traceback.New("user not found")
+//	err := traceback.WrapfWith(inner, []traceback.Option{traceback.ReuseTrace()}, "failed to fetch user %d", 123)
+//	s.Equal("failed to fetch user 123: user not found", err.Error())
+//	s.Equal(inner.Frames(), err.Frames())
+func WrapfWith(err error, opts []Option, format string, args ...any) *Error {
+	if err == nil {
+		return nil
+	}
+	msg := fmt.Sprintf(format, args...)
+	return &Error{
+		cause:  fmt.Errorf("%s: %w", msg, err),
+		frames: newOptions(opts).capture(err, 1),
 	}
 }
 
@@ -118,6 +154,17 @@ func (e *Error) Error() string {
 	return ""
 }
 
+// Unwrap returns the underlying cause, so errors.Is and errors.As see through the trace.
+//
+// Example:
+//
+//	err := traceback.Wrap(io.EOF, "failed to read header")
+//	s.True(errors.Is(err, io.EOF))
+//	s.Equal("failed to read header: EOF", errors.Unwrap(err).Error())
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
 // Frames returns the captured stack frames.
 //
 // Example:
@@ -125,8 +172,8 @@ func (e *Error) Error() string {
 //	err := traceback.New("something went wrong")
 //	frames := err.Frames()
 //	fmt.Println(frames.String())
-func (e *Error) Frames() frame.Frames {
-	return e.frames
+func (e *Error) Frames() Frames {
+	return Frames{e.frames}
 }
 
 // String returns a formatted stack trace string.
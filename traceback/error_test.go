@@ -1,7 +1,10 @@
 package traceback_test
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -37,6 +40,23 @@ func (s *Suite) TestErrorf() {
 	fmt.Println(err.Error())
 	// testdoc end
 	s.Error(err)
+
+	top, ok := err.Frames().Top()
+	s.True(ok)
+	s.Contains(top.Function, "(*Suite).TestErrorf")
+}
+
+func (s *Suite) TestErrorfWith() {
+	// testdoc begin ErrorfWith
+	err := traceback.ErrorfWith([]traceback.Option{traceback.Depth(1)}, "failed to process item %d", 42)
+	s.Equal("failed to process item 42", err.Error())
+	s.Equal(1, err.Frames().Len())
+	// testdoc end
+
+	top, ok := err.Frames().Top()
+	s.True(ok)
+	s.Contains(top.Function, "(*Suite).TestErrorfWith")
+	s.True(errors.Is(traceback.ErrorfWith(nil, "wrapped: %w", io.EOF), io.EOF))
 }
 
 func (s *Suite) TestFrom() {
@@ -81,9 +101,27 @@ func (s *Suite) TestWrapf() {
 	s.Nil(result)
 	s.Error(err)
 
+	top, ok := traceback.Wrapf(io.EOF, "read").Frames().Top()
+	s.True(ok)
+	s.Contains(top.Function, "(*Suite).TestWrapf")
 	s.Nil(traceback.Wrapf(nil, "format %d", 1))
 }
 
+func (s *Suite) TestWrapfWith() {
+	// testdoc begin WrapfWith
+	inner := traceback.New("user not found")
+	err := traceback.WrapfWith(inner, []traceback.Option{traceback.ReuseTrace()}, "failed to fetch user %d", 123)
+	s.Equal("failed to fetch user 123: user not found", err.Error())
+	s.Equal(inner.Frames(), err.Frames())
+	// testdoc end
+
+	s.Nil(traceback.WrapfWith(nil, nil, "format %d", 1))
+
+	plain := traceback.WrapfWith(io.EOF, nil, "read %s", "header")
+	s.True(errors.Is(plain, io.EOF))
+	s.Contains(plain.Frames().String(), "(*Suite).TestWrapfWith()")
+}
+
 func (s *Suite) TestError() {
 	// testdoc begin Error.Error
 	err := traceback.New("something went wrong")
@@ -92,6 +130,82 @@ func (s *Suite) TestError() {
 	s.Error(err)
 }
 
+func (s *Suite) TestUnwrap() {
+	// testdoc begin Error.Unwrap
+	err := traceback.Wrap(io.EOF, "failed to read header")
+	s.True(errors.Is(err, io.EOF))
+	s.Equal("failed to read header: EOF", errors.Unwrap(err).Error())
+	// testdoc end
+
+	s.Nil(errors.Unwrap(errors.Unwrap(traceback.New("no cause"))))
+}
+
+// codeError is a typed error used to check errors.As through a trace.
+type codeError struct{ code int }
+
+func (e *codeError) Error() string { return fmt.Sprintf("code %d", e.code) }
+
+func (s *Suite) TestIsAsMatrix() {
+	errSentinel := errors.New("sentinel")
+	typed := &codeError{code: 7}
+	pathErr := &fs.PathError{Op: "open", Path: "/missing", Err: fs.ErrNotExist}
+
+	causes := map[string]struct {
+		err      error
+		sentinel error
+	}{
+		"sentinel": {errSentinel, errSentinel},
+		"typed":    {typed, nil},
+		"wrapped":  {fmt.Errorf("context: %w", pathErr), fs.ErrNotExist},
+		"joined":   {errors.Join(errSentinel, typed, pathErr), errSentinel},
+	}
+	constructors := map[string]func(error) error{
+		"From":   func(err error) error { return traceback.From(err) },
+		"Wrap":   func(err error) error { return traceback.Wrap(err, "wrap") },
+		"Wrapf":  func(err error) error { return traceback.Wrapf(err, "wrap %d", 1) },
+		"Errorf": func(err error) error { return traceback.Errorf("errorf: %w", err) },
+		"WrapfWith": func(err error) error {
+			return traceback.WrapfWith(err, []traceback.Option{traceback.ReuseTrace()}, "wrap %d", 1)
+		},
+		"nested": func(err error) error { return traceback.Wrap(traceback.From(err), "outer") },
+		"fmt":    func(err error) error { return fmt.Errorf("fmt: %w", traceback.From(err)) },
+	}
+
+	for causeName, cause := range causes {
+		for ctorName, ctor := range constructors {
+			name := ctorName + "/" + causeName
+			err := ctor(cause.err)
+
+			s.True(errors.Is(err, cause.err), name)
+			if cause.sentinel != nil {
+				s.True(errors.Is(err, cause.sentinel), name)
+			}
+			s.False(errors.Is(err, io.EOF), name)
+
+			var te *traceback.Error
+			s.True(errors.As(err, &te), name)
+			s.Greater(te.Frames().Len(), 0, name)
+
+			var ce *codeError
+			if errors.As(cause.err, &ce) {
+				s.True(errors.As(err, &ce), name)
+				s.Equal(7, ce.code, name)
+			} else {
+				s.False(errors.As(err, &ce), name)
+			}
+
+			var pe *fs.PathError
+			s.Equal(errors.As(cause.err, &pe), errors.As(err, &pe), name)
+		}
+	}
+
+	// errors.Join of traced errors is still searchable
+	joined := errors.Join(traceback.From(errSentinel), traceback.Wrap(typed, "typed"))
+	s.True(errors.Is(joined, errSentinel))
+	var ce *codeError
+	s.True(errors.As(joined, &ce))
+}
+
 func (s *Suite) TestFrames() {
 	// testdoc begin Error.Frames
 	err := traceback.New("something went wrong")
@@ -108,3 +222,31 @@ func (s *Suite) TestString() {
 	// testdoc end
 	s.Error(err)
 }
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = traceback.New("something went wrong")
+	}
+}
+
+func BenchmarkNew_Printed(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = fmt.Sprintf("%+v", traceback.New("something went wrong"))
+	}
+}
+
+func BenchmarkWrap(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = traceback.Wrap(io.EOF, "failed to read")
+	}
+}
+
+func BenchmarkWrap_Printed(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = fmt.Sprintf("%+v", traceback.Wrap(io.EOF, "failed to read"))
+	}
+}
@@ -0,0 +1,43 @@
+package traceback
+
+import (
+	"fmt"
+	"io"
+)
+
+var _ fmt.Formatter = (*Error)(nil)
+
+// Format implements fmt.Formatter.
+// %s, %v and %#v print the message and %q prints it quoted.
+// %+v prints the message followed by the stack trace, then each layer of Chain
+// as a "Caused by:" section whose frames shared with the enclosing layer are elided as "... N more".
+//
+// Example:
+//
+//	err := traceback.Wrap(traceback.New("disk full"), "failed to save")
+//	s.Equal("failed to save: disk full", fmt.Sprintf("%v", err))
+//	s.Equal(`"failed to save: disk full"`, fmt.Sprintf("%q", err))
+//
+//	trace := fmt.Sprintf("%+v", err)
+//	s.True(strings.HasPrefix(trace, "failed to save: disk full\n"))
+//	s.Contains(trace, "\nCaused by: disk full\n")
+func (e *Error) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			io.WriteString(f, renderChain(Chain(e)))
+			return
+		}
+		if f.Flag('#') {
+			io.WriteString(f, e.Error())
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, verb), e.Error())
+	case 's', 'q':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), e.Error())
+	default:
+		fmt.Fprintf(f, "%%!%c(*traceback.Error=%s)", verb, e.Error())
+	}
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,89 @@
+package traceback_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/traceback"
+)
+
+type FormatSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func (s *FormatSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func TestFormatSuite(t *testing.T) {
+	suite.Run(t, new(FormatSuite))
+}
+
+func (s *FormatSuite) TestFormat() {
+	// testdoc begin Error.Format
+	err := traceback.Wrap(traceback.New("disk full"), "failed to save")
+	s.Equal("failed to save: disk full", fmt.Sprintf("%v", err))
+	s.Equal(`"failed to save: disk full"`, fmt.Sprintf("%q", err))
+
+	trace := fmt.Sprintf("%+v", err)
+	s.True(strings.HasPrefix(trace, "failed to save: disk full\n"))
+	s.Contains(trace, "\nCaused by: disk full\n")
+	// testdoc end
+}
+
+func (s *FormatSuite) TestVerbs() {
+	err := traceback.New("boom")
+	s.Equal("boom", fmt.Sprintf("%s", err))
+	s.Equal("boom", fmt.Sprint(err))
+	s.Equal("  boom", fmt.Sprintf("%6s", err))
+	s.Equal("boom  ", fmt.Sprintf("%-6v", err))
+	s.Equal("boom", fmt.Sprintf("%#v", err))
+	s.Equal(`"boom"`, fmt.Sprintf("%q", err))
+	s.Equal("%!d(*traceback.Error=boom)", fmt.Sprintf("%d", err))
+	s.Equal("wrapped: boom", fmt.Errorf("wrapped: %w", err).Error())
+}
+
+func (s *FormatSuite) TestPlusV() {
+	err := traceback.New("boom")
+	trace := fmt.Sprintf("%+v", err)
+	s.Equal("boom\n"+strings.TrimSuffix(err.Frames().String(), "\n"), trace)
+	s.Contains(trace, "traceback_test.(*FormatSuite).TestPlusV()\n\t")
+	s.Contains(trace, "format_test.go:")
+	s.NotContains(trace, "Caused by:")
+}
+
+func (s *FormatSuite) TestPlusVCauseChain() {
+	root := traceback.From(io.EOF)
+	middle := fmt.Errorf("middle: %w", root) // untraced layers are skipped
+	outer := traceback.Wrapf(middle, "outer %d", 1)
+
+	trace := fmt.Sprintf("%+v", outer)
+	lines := strings.Split(trace, "\n")
+	s.Equal("outer 1: middle: EOF", lines[0])
+	s.Equal(1, strings.Count(trace, "Caused by:"))
+	s.Contains(trace, "\nCaused by: EOF\n")
+	shared := commonSuffixLen(root.Frames(), outer.Frames())
+	s.Greater(shared, 0)
+	s.Contains(trace, fmt.Sprintf("\n\t... %d more", shared))
+	s.Equal(outer.Frames().Len()+root.Frames().Len()-shared+1, strings.Count(trace, "\t"))
+
+	// a plain cause adds no section
+	plain := traceback.Wrap(errors.New("plain"), "outer")
+	s.NotContains(fmt.Sprintf("%+v", plain), "Caused by:")
+}
+
+// commonSuffixLen counts the outermost frames two traces share.
+func commonSuffixLen(a, b traceback.Frames) int {
+	n := 0
+	for n < a.Len() && n < b.Len() && a.At(a.Len()-1-n) == b.At(b.Len()-1-n) {
+		n++
+	}
+	return n
+}
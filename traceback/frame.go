@@ -0,0 +1,38 @@
+package traceback
+
+import "github.com/ysuzuki19/collections-go/traceback/internal/frame"
+
+// Frame is a single stack frame.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// String returns the frame in Go's standard stack trace style.
+//
+// Example:
+//
+//	f := traceback.Frame{
+//		Function: "main.doSomething",
+//		File:     "/path/to/file.go",
+//		Line:     42,
+//	}
+//	s.Equal("main.doSomething()\n\t/path/to/file.go:42", f.String())
+func (f Frame) String() string {
+	return frame.Frame(f).String()
+}
+
+// Format returns the frame rendered by formatter.
+//
+// Example:
+//
+//	f := traceback.Frame{Function: "main.doSomething", File: "/path/to/file.go", Line: 42}
+//	s.Equal("/path/to/file.go:42", f.Format(func(f traceback.Frame) string {
+//		return fmt.Sprintf("%s:%d", f.File, f.Line)
+//	}))
+func (f Frame) Format(formatter Formatter) string {
+	return formatter(f)
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
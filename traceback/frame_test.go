@@ -0,0 +1,53 @@
+package traceback_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/traceback"
+)
+
+type FrameSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func (s *FrameSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func TestFrameSuite(t *testing.T) {
+	suite.Run(t, new(FrameSuite))
+}
+
+func (s *FrameSuite) TestString() {
+	// testdoc begin Frame.String
+	f := traceback.Frame{
+		Function: "main.doSomething",
+		File:     "/path/to/file.go",
+		Line:     42,
+	}
+	s.Equal("main.doSomething()\n\t/path/to/file.go:42", f.String())
+	// testdoc end
+}
+
+func (s *FrameSuite) TestFormat() {
+	// testdoc begin Frame.Format
+	f := traceback.Frame{Function: "main.doSomething", File: "/path/to/file.go", Line: 42}
+	s.Equal("/path/to/file.go:42", f.Format(func(f traceback.Frame) string {
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}))
+	// testdoc end
+
+	// FormatterArgs is the same type
+	var args traceback.FormatterArgs = f
+	s.Equal(f.String(), args.String())
+
+	// Formatter is an alias, so plain function values and types convert freely
+	var formatter traceback.Formatter = func(args traceback.FormatterArgs) string { return args.Function }
+	var plain func(traceback.Frame) string = formatter
+	s.Equal("main.doSomething", f.Format(plain))
+}
@@ -0,0 +1,147 @@
+package traceback
+
+import (
+	"iter"
+
+	"github.com/ysuzuki19/collections-go/traceback/internal/frame"
+)
+
+// Frames is a captured stack trace, ordered from the innermost call outwards.
+// Frames are symbolized on first access, and the result is cached and shared by copies.
+type Frames struct {
+	frames frame.Frames
+}
+
+// String returns the frames in Go's standard stack trace style, one frame per two lines.
+//
+// Example:
+//
+//	frames := traceback.New("something went wrong").Frames()
+//	s.Contains(frames.String(), "(*FramesSuite).TestString()\n\t")
+func (fs Frames) String() string {
+	return fs.frames.String()
+}
+
+// Format returns the frames rendered by formatter, one frame per line.
+//
+// Example:
+//
+//	frames := traceback.New("something went wrong").Frames()
+//	out := frames.Format(func(f traceback.Frame) string {
+//		return f.Function
+//	})
+//	s.True(strings.HasPrefix(out, "github.com/ysuzuki19/collections-go/traceback_test.(*FramesSuite).TestFormat\n"))
+func (fs Frames) Format(formatter Formatter) string {
+	return fs.frames.Format(func(f frame.Frame) string {
+		return formatter(Frame(f))
+	})
+}
+
+// Push appends a frame after the outermost one.
+//
+// Example:
+//
+//	frames := traceback.Frames{}
+//	frames.Push(traceback.Frame{Function: "main.main", File: "main.go", Line: 5})
+//	s.Equal(1, frames.Len())
+//	s.Equal("main.main()\n\tmain.go:5\n", frames.String())
+func (fs *Frames) Push(f Frame) {
+	fs.frames.Push(frame.Frame(f))
+}
+
+// Len returns the number of frames.
+//
+// Example:
+//
+//	s.Greater(traceback.New("something went wrong").Frames().Len(), 0)
+//	s.Equal(0, traceback.FramesOf(nil).Len())
+func (fs Frames) Len() int {
+	return fs.frames.Len()
+}
+
+// Truncated reports whether the stack was deeper than the capture depth,
+// so that its outermost frames are missing.
+//
+// Example:
+//
+//	s.False(traceback.New("shallow").Frames().Truncated())
+//	s.True(traceback.New("deep", traceback.Depth(1)).Frames().Truncated())
+func (fs Frames) Truncated() bool {
+	return fs.frames.Truncated()
+}
+
+// All returns an iterator over the frames with their indexes, from the innermost call outwards.
+//
+// Example:
+//
+//	frames := traceback.New("something went wrong").Frames()
+//	for i, f := range frames.All() {
+//		s.Equal(frames.At(i), f)
+//	}
+func (fs Frames) All() iter.Seq2[int, Frame] {
+	return func(yield func(int, Frame) bool) {
+		for i, f := range fs.frames.All() {
+			if !yield(i, Frame(f)) {
+				return
+			}
+		}
+	}
+}
+
+// At returns the frame at index i, where index 0 is the innermost call.
+// It panics if i is out of range.
+//
+// Example:
+//
+//	frames := traceback.New("something went wrong").Frames()
+//	s.True(strings.HasSuffix(frames.At(0).Function, "(*FramesSuite).TestAt"))
+func (fs Frames) At(i int) Frame {
+	return Frame(fs.frames.At(i))
+}
+
+// Top returns the innermost frame, where the error was created.
+// Returns false if there are no frames.
+//
+// Example:
+//
+//	top, ok := traceback.New("something went wrong").Frames().Top()
+//	s.True(ok)
+//	s.True(strings.HasSuffix(top.Function, "(*FramesSuite).TestTop"))
+//
+//	_, ok = traceback.FramesOf(io.EOF).Top()
+//	s.False(ok)
+func (fs Frames) Top() (Frame, bool) {
+	f, ok := fs.frames.Top()
+	return Frame(f), ok
+}
+
+// Filter returns the frames that satisfy pred, in the same order.
+// The result is truncated if the frames are.
+//
+// Example:
+//
+//	frames := traceback.New("something went wrong").Frames()
+//	own := frames.Filter(func(f traceback.Frame) bool {
+//		return strings.HasPrefix(f.Function, "github.com/ysuzuki19/collections-go/")
+//	})
+//	s.Equal(1, own.Len())
+func (fs Frames) Filter(pred func(Frame) bool) Frames {
+	return Frames{fs.frames.Filter(func(f frame.Frame) bool {
+		return pred(Frame(f))
+	})}
+}
+
+// Slice returns the frames from index i up to but not including j.
+// The result is truncated if the frames are and j is the end.
+// It panics if the indexes are out of range.
+//
+// Example:
+//
+//	frames := traceback.New("something went wrong").Frames()
+//	s.Equal(frames.At(1), frames.Slice(1, 2).At(0))
+//	s.Equal(frames.Len()-1, frames.Slice(1, frames.Len()).Len())
+func (fs Frames) Slice(i, j int) Frames {
+	return Frames{fs.frames.Slice(i, j)}
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -0,0 +1,134 @@
+package traceback_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/traceback"
+)
+
+type FramesSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func (s *FramesSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func TestFramesSuite(t *testing.T) {
+	suite.Run(t, new(FramesSuite))
+}
+
+func (s *FramesSuite) TestString() {
+	// testdoc begin Frames.String
+	frames := traceback.New("something went wrong").Frames()
+	s.Contains(frames.String(), "(*FramesSuite).TestString()\n\t")
+	// testdoc end
+}
+
+func (s *FramesSuite) TestFormat() {
+	// testdoc begin Frames.Format
+	frames := traceback.New("something went wrong").Frames()
+	out := frames.Format(func(f traceback.Frame) string {
+		return f.Function
+	})
+	s.True(strings.HasPrefix(out, "github.com/ysuzuki19/collections-go/traceback_test.(*FramesSuite).TestFormat\n"))
+	// testdoc end
+	s.Equal(frames.Len(), strings.Count(out, "\n"))
+}
+
+func (s *FramesSuite) TestPush() {
+	// testdoc begin Frames.Push
+	frames := traceback.Frames{}
+	frames.Push(traceback.Frame{Function: "main.main", File: "main.go", Line: 5})
+	s.Equal(1, frames.Len())
+	s.Equal("main.main()\n\tmain.go:5\n", frames.String())
+	// testdoc end
+
+	// pushing onto a copy of a captured trace leaves the error untouched
+	err := traceback.New("something went wrong")
+	captured := err.Frames()
+	captured.Push(traceback.FormatterArgs{Function: "extra"})
+	s.Equal(err.Frames().Len()+1, captured.Len())
+	s.NotContains(err.Frames().String(), "extra")
+}
+
+func (s *FramesSuite) TestLen() {
+	// testdoc begin Frames.Len
+	s.Greater(traceback.New("something went wrong").Frames().Len(), 0)
+	s.Equal(0, traceback.FramesOf(nil).Len())
+	// testdoc end
+}
+
+func (s *FramesSuite) TestTruncated() {
+	// testdoc begin Frames.Truncated
+	s.False(traceback.New("shallow").Frames().Truncated())
+	s.True(traceback.New("deep", traceback.Depth(1)).Frames().Truncated())
+	// testdoc end
+}
+
+func (s *FramesSuite) TestAll() {
+	// testdoc begin Frames.All
+	frames := traceback.New("something went wrong").Frames()
+	for i, f := range frames.All() {
+		s.Equal(frames.At(i), f)
+	}
+	// testdoc end
+
+	n := 0
+	for range frames.All() {
+		n++
+		break
+	}
+	s.Equal(1, n)
+}
+
+func (s *FramesSuite) TestAt() {
+	// testdoc begin Frames.At
+	frames := traceback.New("something went wrong").Frames()
+	s.True(strings.HasSuffix(frames.At(0).Function, "(*FramesSuite).TestAt"))
+	// testdoc end
+
+	s.Contains(frames.At(0).File, "frames_test.go")
+	s.Greater(frames.At(0).Line, 0)
+	s.Panics(func() { frames.At(frames.Len()) })
+}
+
+func (s *FramesSuite) TestTop() {
+	// testdoc begin Frames.Top
+	top, ok := traceback.New("something went wrong").Frames().Top()
+	s.True(ok)
+	s.True(strings.HasSuffix(top.Function, "(*FramesSuite).TestTop"))
+
+	_, ok = traceback.FramesOf(io.EOF).Top()
+	s.False(ok)
+	// testdoc end
+}
+
+func (s *FramesSuite) TestFilter() {
+	// testdoc begin Frames.Filter
+	frames := traceback.New("something went wrong").Frames()
+	own := frames.Filter(func(f traceback.Frame) bool {
+		return strings.HasPrefix(f.Function, "github.com/ysuzuki19/collections-go/")
+	})
+	s.Equal(1, own.Len())
+	// testdoc end
+
+	s.Equal(frames.String(), frames.Filter(func(traceback.Frame) bool { return true }).String())
+	s.True(traceback.New("deep", traceback.Depth(2)).Frames().Filter(func(traceback.Frame) bool { return false }).Truncated())
+}
+
+func (s *FramesSuite) TestSlice() {
+	// testdoc begin Frames.Slice
+	frames := traceback.New("something went wrong").Frames()
+	s.Equal(frames.At(1), frames.Slice(1, 2).At(0))
+	s.Equal(frames.Len()-1, frames.Slice(1, frames.Len()).Len())
+	// testdoc end
+
+	s.Panics(func() { frames.Slice(0, frames.Len()+1) })
+}
@@ -2,33 +2,96 @@ package frame
 
 import (
 	"runtime"
+	"slices"
+	"sync"
 )
 
+// DefaultDepth is the number of program counters Capture records.
+const DefaultDepth = 32
+
+// trace holds captured program counters and symbolizes them once, on first access.
+// It is shared by copies of Frames, so the cache is too.
+type trace struct {
+	pcs       []uintptr
+	depth     int
+	truncated bool // more program counters than depth were on the stack
+
+	once   sync.Once
+	frames []Frame
+	cut    bool // the program counters expanded beyond depth frames
+}
+
+func (t *trace) resolve() []Frame {
+	t.once.Do(func() {
+		callersFrames := runtime.CallersFrames(t.pcs)
+		for {
+			callersFrame, more := callersFrames.Next()
+			if len(t.frames) == t.depth {
+				t.cut = true
+				break
+			}
+			t.frames = append(t.frames, Frame{
+				Function: callersFrame.Function,
+				File:     callersFrame.File,
+				Line:     callersFrame.Line,
+			})
+			if !more {
+				break
+			}
+		}
+	})
+	return t.frames
+}
+
+func (t *trace) isTruncated() bool {
+	if t.truncated {
+		return true
+	}
+	t.resolve()
+	return t.cut
+}
+
 // Capture captures the current stack frames, skipping the specified number of frames.
+// At most DefaultDepth program counters are recorded.
 //
 // Example:
 //
 //	frames := frame.Capture(0)
 func Capture(skip int) Frames {
-	var frames Frames
-
-	pcs := make([]uintptr, 32)
-	n := runtime.Callers(skip+1, pcs)
-	pcs = pcs[:n]
-
-	callersFrames := runtime.CallersFrames(pcs)
-	for {
-		callersFrame, more := callersFrames.Next()
-		frames.Push(Frame{
-			Function: callersFrame.Function,
-			File:     callersFrame.File,
-			Line:     callersFrame.Line,
-		})
-		if !more {
-			break
-		}
+	return CaptureDepth(skip+1, DefaultDepth)
+}
+
+// CaptureDepth captures at most depth frames of the current stack,
+// skipping the specified number of frames. Deeper stacks are cut off and marked as truncated.
+// The stack is recorded as program counters, which are symbolized into Frame values
+// only when the frames are first read. Program counters are not guaranteed to map one-to-one
+// to frames because of inlining, so the frames are cut off at depth again when they are symbolized.
+// A depth of zero or less captures nothing.
+//
+// Example:
+//
+//	frames := frame.CaptureDepth(0, 2)
+//	require.Equal(2, frames.Len()) // frame.CaptureDepth>TestCaptureDepth
+//	require.True(frames.Truncated())
+func CaptureDepth(skip int, depth int) Frames {
+	if depth <= 0 {
+		return Frames{}
+	}
+
+	// one extra slot tells whether the stack was cut off
+	var buf [DefaultDepth + 1]uintptr
+	pcs := buf[:]
+	if depth+1 > len(buf) {
+		pcs = make([]uintptr, depth+1)
+	}
+	n := runtime.Callers(skip+1, pcs[:depth+1])
+	truncated := n > depth
+	if truncated {
+		n = depth
+	}
+	return Frames{
+		trace: &trace{pcs: slices.Clone(pcs[:n]), depth: depth, truncated: truncated},
 	}
-	return frames
 }
 
 //go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
@@ -1,6 +1,8 @@
 package frame_test
 
 import (
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -37,3 +39,114 @@ func TestCapture(t *testing.T) {
 		require.Equal(4, frames.Len()) // runtime>testing>func1>TestCapture
 	}
 }
+
+func TestCaptureDepth(t *testing.T) {
+	require := require.New(t)
+
+	{
+		// testdoc begin CaptureDepth
+		frames := frame.CaptureDepth(0, 2)
+		require.Equal(2, frames.Len()) // frame.CaptureDepth>TestCaptureDepth
+		require.True(frames.Truncated())
+		// testdoc end
+		require.Contains(frames.String(), "frame_test.TestCaptureDepth()")
+	}
+
+	{
+		frames := frame.CaptureDepth(1, 100)
+		require.Equal(3, frames.Len()) // runtime>testing>TestCaptureDepth
+		require.False(frames.Truncated())
+	}
+
+	{
+		// the exact depth is not truncated
+		frames := frame.CaptureDepth(1, 3)
+		require.Equal(3, frames.Len())
+		require.False(frames.Truncated())
+	}
+
+	{
+		frames := frame.CaptureDepth(0, 0)
+		require.Equal(0, frames.Len())
+		require.False(frames.Truncated())
+	}
+
+	{
+		var recurse func(n int) frame.Frames
+		recurse = func(n int) frame.Frames {
+			if n == 0 {
+				return frame.Capture(0)
+			}
+			return recurse(n - 1)
+		}
+		frames := recurse(frame.DefaultDepth)
+		require.Equal(frame.DefaultDepth, frames.Len())
+		require.True(frames.Truncated())
+
+		frames = frame.CaptureDepth(0, 2*frame.DefaultDepth)
+		require.False(frames.Truncated())
+	}
+}
+
+// captureInlined is small enough to be inlined into its caller,
+// which must not let the frames exceed the capture depth.
+func captureInlined(depth int) frame.Frames {
+	return frame.CaptureDepth(0, depth)
+}
+
+func TestCaptureDepth_Inlined(t *testing.T) {
+	require := require.New(t)
+
+	full := captureInlined(100)
+	require.False(full.Truncated())
+	require.Contains(full.String(), "frame_test.captureInlined()")
+
+	for depth := 1; depth <= full.Len()+1; depth++ {
+		frames := captureInlined(depth)
+		require.Equal(min(depth, full.Len()), frames.Len(), depth)
+		require.Equal(depth < full.Len(), frames.Truncated(), depth)
+		function := func(f frame.Frame) string { return f.Function }
+		require.Equal(full.Slice(0, frames.Len()).Format(function), frames.Format(function), depth)
+	}
+}
+
+func TestCapture_SharedResolution(t *testing.T) {
+	require := require.New(t)
+
+	frames := frame.Capture(0)
+	copied := frames
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = copied.String()
+		}()
+	}
+	wg.Wait()
+	for _, r := range results {
+		require.Equal(frames.String(), r)
+	}
+	require.True(strings.HasPrefix(frames.String(), "github.com/ysuzuki19/collections-go/traceback/internal/frame.Capture()"))
+
+	// pushing onto a copy leaves the captured frames untouched
+	copied.Push(frame.Frame{Function: "extra"})
+	require.Equal(frames.Len()+1, copied.Len())
+	require.NotContains(frames.String(), "extra")
+}
+
+func BenchmarkCapture(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		frame.Capture(0)
+	}
+}
+
+func BenchmarkCapture_Resolved(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		frame.Capture(0).Len()
+	}
+}
@@ -1,12 +1,25 @@
 package frame
 
 import (
+	"iter"
+	"slices"
 	"strings"
 )
 
 // Frames represents a collection of stack frames.
+// Frames returned by Capture hold raw program counters until they are first read.
 type Frames struct {
-	frames []Frame
+	frames    []Frame
+	trace     *trace
+	truncated bool
+}
+
+// list returns the frames, symbolizing captured program counters on first use.
+func (fs Frames) list() []Frame {
+	if fs.trace != nil {
+		return fs.trace.resolve()
+	}
+	return fs.frames
 }
 
 // String returns a formatted string representation of the frames.
@@ -17,7 +30,7 @@ type Frames struct {
 //	s.Equal("", frames.String())
 func (fs Frames) String() string {
 	var sb strings.Builder
-	for _, f := range fs.frames {
+	for _, f := range fs.list() {
 		sb.WriteString(f.String())
 		sb.WriteString("\n")
 	}
@@ -37,7 +50,7 @@ func (fs Frames) String() string {
 //	s.Equal("func1 at file1.go:10\nfunc2 at file2.go:20\n", frames.Format(formatter))
 func (fs Frames) Format(formatter Formatter) string {
 	var sb strings.Builder
-	for _, f := range fs.frames {
+	for _, f := range fs.list() {
 		sb.WriteString(f.Format(formatter))
 		sb.WriteString("\n")
 	}
@@ -52,6 +65,11 @@ func (fs Frames) Format(formatter Formatter) string {
 //	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
 //	s.Equal(1, frames.Len())
 func (fs *Frames) Push(other Frame) {
+	if fs.trace != nil {
+		// the resolved frames are shared with copies, so they are copied before appending
+		fs.frames = slices.Clone(fs.trace.resolve())
+		fs.trace = nil
+	}
 	fs.frames = append(fs.frames, other)
 }
 
@@ -64,7 +82,145 @@ func (fs *Frames) Push(other Frame) {
 //	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
 //	s.Equal(1, frames.Len())
 func (fs Frames) Len() int {
-	return len(fs.frames)
+	return len(fs.list())
+}
+
+// Truncated reports whether the captured stack was deeper than the capture depth,
+// so that its outermost frames are missing.
+//
+// Example:
+//
+//	s.False(frame.Capture(0).Truncated())
+//	s.True(frame.CaptureDepth(0, 1).Truncated())
+func (fs Frames) Truncated() bool {
+	if fs.trace != nil {
+		return fs.trace.isTruncated()
+	}
+	return fs.truncated
+}
+
+// All returns an iterator over the frames with their indexes, from the innermost call outwards.
+//
+// Example:
+//
+//	frames := frame.Frames{}
+//	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+//	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+//	var functions []string
+//	for _, f := range frames.All() {
+//		functions = append(functions, f.Function)
+//	}
+//	s.Equal([]string{"func1", "func2"}, functions)
+func (fs Frames) All() iter.Seq2[int, Frame] {
+	return slices.All(fs.list())
+}
+
+// At returns the frame at index i, where index 0 is the innermost call.
+// It panics if i is out of range.
+//
+// Example:
+//
+//	frames := frame.Frames{}
+//	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+//	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+//	s.Equal("func2", frames.At(1).Function)
+func (fs Frames) At(i int) Frame {
+	return fs.list()[i]
+}
+
+// Top returns the innermost frame, where the stack was captured.
+// Returns false if there are no frames.
+//
+// Example:
+//
+//	frames := frame.Frames{}
+//	_, ok := frames.Top()
+//	s.False(ok)
+//	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+//	top, ok := frames.Top()
+//	s.True(ok)
+//	s.Equal("func1", top.Function)
+func (fs Frames) Top() (Frame, bool) {
+	frames := fs.list()
+	if len(frames) == 0 {
+		return Frame{}, false
+	}
+	return frames[0], true
+}
+
+// Filter returns the frames that satisfy pred, in the same order.
+// The result is truncated if the frames are.
+//
+// Example:
+//
+//	frames := frame.Frames{}
+//	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "main.run"})
+//	frames.Push(frame.Frame{File: "proc.go", Line: 20, Function: "runtime.main"})
+//	filtered := frames.Filter(func(f frame.Frame) bool {
+//		return !strings.HasPrefix(f.Function, "runtime.")
+//	})
+//	s.Equal(1, filtered.Len())
+//	s.Equal("main.run", filtered.At(0).Function)
+func (fs Frames) Filter(pred func(Frame) bool) Frames {
+	var frames []Frame
+	for _, f := range fs.list() {
+		if pred(f) {
+			frames = append(frames, f)
+		}
+	}
+	return Frames{frames: frames, truncated: fs.Truncated()}
+}
+
+// Slice returns the frames from index i up to but not including j.
+// The result is truncated if the frames are and j is the end.
+// It panics if the indexes are out of range.
+//
+// Example:
+//
+//	frames := frame.Frames{}
+//	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+//	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+//	frames.Push(frame.Frame{File: "file3.go", Line: 30, Function: "func3"})
+//	s.Equal("func2()\n\tfile2.go:20\n", frames.Slice(1, 2).String())
+func (fs Frames) Slice(i, j int) Frames {
+	frames := fs.list()
+	return Frames{frames: slices.Clip(frames)[i:j:j], truncated: fs.Truncated() && j == len(frames)}
+}
+
+// CommonSuffixLen returns the number of trailing frames that are equal in both collections.
+// The trailing frames are the outermost callers, which traces captured on the same goroutine share.
+//
+// Example:
+//
+//	main := frame.Frame{File: "main.go", Line: 5, Function: "main.main"}
+//	fs1 := frame.Frames{}
+//	fs1.Push(frame.Frame{File: "a.go", Line: 10, Function: "a"})
+//	fs1.Push(main)
+//	fs2 := frame.Frames{}
+//	fs2.Push(frame.Frame{File: "b.go", Line: 20, Function: "b"})
+//	fs2.Push(main)
+//	s.Equal(1, fs1.CommonSuffixLen(fs2))
+//	s.Equal(2, fs1.CommonSuffixLen(fs1))
+func (fs Frames) CommonSuffixLen(other Frames) int {
+	a, b := fs.list(), other.list()
+	n := 0
+	for n < len(a) && n < len(b) && a[len(a)-1-n] == b[len(b)-1-n] {
+		n++
+	}
+	return n
+}
+
+// TrimSuffix returns the frames without the last n frames.
+//
+// Example:
+//
+//	frames := frame.Frames{}
+//	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+//	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+//	s.Equal("func1()\n\tfile1.go:10\n", frames.TrimSuffix(1).String())
+//	s.Equal(0, frames.TrimSuffix(5).Len())
+func (fs Frames) TrimSuffix(n int) Frames {
+	return fs.Slice(0, fs.Len()-min(n, fs.Len()))
 }
 
 //go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
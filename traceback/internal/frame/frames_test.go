@@ -2,6 +2,7 @@ package frame_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -70,3 +71,126 @@ func (s *FramesSuite) TestFormat() {
 	s.Equal("func1 at file1.go:10\nfunc2 at file2.go:20\n", frames.Format(formatter))
 	// testdoc end
 }
+
+func (s *FramesSuite) TestCommonSuffixLen() {
+	// testdoc begin Frames.CommonSuffixLen
+	main := frame.Frame{File: "main.go", Line: 5, Function: "main.main"}
+	fs1 := frame.Frames{}
+	fs1.Push(frame.Frame{File: "a.go", Line: 10, Function: "a"})
+	fs1.Push(main)
+	fs2 := frame.Frames{}
+	fs2.Push(frame.Frame{File: "b.go", Line: 20, Function: "b"})
+	fs2.Push(main)
+	s.Equal(1, fs1.CommonSuffixLen(fs2))
+	s.Equal(2, fs1.CommonSuffixLen(fs1))
+	// testdoc end
+
+	s.Equal(0, fs1.CommonSuffixLen(frame.Frames{}))
+	fs2.Push(frame.Frame{File: "c.go", Line: 30, Function: "c"})
+	s.Equal(0, fs1.CommonSuffixLen(fs2))
+}
+
+func (s *FramesSuite) TestTrimSuffix() {
+	// testdoc begin Frames.TrimSuffix
+	frames := frame.Frames{}
+	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+	s.Equal("func1()\n\tfile1.go:10\n", frames.TrimSuffix(1).String())
+	s.Equal(0, frames.TrimSuffix(5).Len())
+	// testdoc end
+
+	s.Equal(frames, frames.TrimSuffix(0))
+
+	// appending to a trimmed copy does not overwrite the original
+	trimmed := frames.TrimSuffix(1)
+	trimmed.Push(frame.Frame{File: "file3.go", Line: 30, Function: "func3"})
+	s.Equal("func1()\n\tfile1.go:10\nfunc2()\n\tfile2.go:20\n", frames.String())
+
+	captured := frame.Capture(0)
+	s.Equal(captured.Len()-1, captured.TrimSuffix(1).Len())
+}
+
+func (s *FramesSuite) TestTruncated() {
+	// testdoc begin Frames.Truncated
+	s.False(frame.Capture(0).Truncated())
+	s.True(frame.CaptureDepth(0, 1).Truncated())
+	// testdoc end
+
+	s.False(frame.Frames{}.Truncated())
+}
+
+func (s *FramesSuite) TestAll() {
+	// testdoc begin Frames.All
+	frames := frame.Frames{}
+	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+	var functions []string
+	for _, f := range frames.All() {
+		functions = append(functions, f.Function)
+	}
+	s.Equal([]string{"func1", "func2"}, functions)
+	// testdoc end
+
+	for i := range frames.All() {
+		s.Equal(0, i)
+		break
+	}
+}
+
+func (s *FramesSuite) TestAt() {
+	// testdoc begin Frames.At
+	frames := frame.Frames{}
+	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+	s.Equal("func2", frames.At(1).Function)
+	// testdoc end
+
+	s.Panics(func() { frames.At(2) })
+	s.Contains(frame.Capture(0).At(0).Function, "frame.Capture")
+}
+
+func (s *FramesSuite) TestTop() {
+	// testdoc begin Frames.Top
+	frames := frame.Frames{}
+	_, ok := frames.Top()
+	s.False(ok)
+	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+	top, ok := frames.Top()
+	s.True(ok)
+	s.Equal("func1", top.Function)
+	// testdoc end
+}
+
+func (s *FramesSuite) TestFilter() {
+	// testdoc begin Frames.Filter
+	frames := frame.Frames{}
+	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "main.run"})
+	frames.Push(frame.Frame{File: "proc.go", Line: 20, Function: "runtime.main"})
+	filtered := frames.Filter(func(f frame.Frame) bool {
+		return !strings.HasPrefix(f.Function, "runtime.")
+	})
+	s.Equal(1, filtered.Len())
+	s.Equal("main.run", filtered.At(0).Function)
+	// testdoc end
+
+	s.Equal(0, frames.Filter(func(frame.Frame) bool { return false }).Len())
+	s.True(frame.CaptureDepth(0, 1).Filter(func(frame.Frame) bool { return true }).Truncated())
+}
+
+func (s *FramesSuite) TestSlice() {
+	// testdoc begin Frames.Slice
+	frames := frame.Frames{}
+	frames.Push(frame.Frame{File: "file1.go", Line: 10, Function: "func1"})
+	frames.Push(frame.Frame{File: "file2.go", Line: 20, Function: "func2"})
+	frames.Push(frame.Frame{File: "file3.go", Line: 30, Function: "func3"})
+	s.Equal("func2()\n\tfile2.go:20\n", frames.Slice(1, 2).String())
+	// testdoc end
+
+	s.Equal(frames, frames.Slice(0, 3))
+	s.Equal(0, frames.Slice(3, 3).Len())
+	s.Panics(func() { frames.Slice(2, 4) })
+
+	captured := frame.CaptureDepth(0, 3)
+	s.True(captured.Slice(1, 3).Truncated())
+	s.False(captured.Slice(0, 2).Truncated())
+}
@@ -0,0 +1,76 @@
+package traceback
+
+import (
+	"errors"
+
+	"github.com/ysuzuki19/collections-go/traceback/internal/frame"
+)
+
+// MaxDepth is the number of stack frames an Error records unless the Depth option overrides it.
+// Deeper stacks are cut off and reported by Frames.Truncated. Zero disables capturing.
+// Set it during initialization, before errors are created concurrently.
+var MaxDepth = frame.DefaultDepth
+
+// Option configures how New, ErrorfWith, From, Wrap and WrapfWith record a trace.
+type Option func(*options)
+
+type options struct {
+	reuseTrace bool
+	depth      int
+}
+
+func newOptions(opts []Option) options {
+	if len(opts) == 0 {
+		// options passed to an Option escape, so the common case avoids the allocation
+		return options{depth: MaxDepth}
+	}
+	o := &options{depth: MaxDepth}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return *o
+}
+
+// Depth records at most n stack frames for this error instead of MaxDepth.
+// Errorf and Wrapf take no options; pass Depth to ErrorfWith or WrapfWith instead.
+//
+// Example:
+//
+//	err := traceback.New("shallow", traceback.Depth(1))
+//	s.Equal(1, err.Frames().Len())
+//	s.True(err.Frames().Truncated())
+func Depth(n int) Option {
+	return func(o *options) {
+		o.depth = n
+	}
+}
+
+// ReuseTrace makes From, Wrap and WrapfWith share the stack trace of a traced cause instead of capturing a new one.
+// The trace then points at where the cause was created rather than where it was wrapped,
+// which avoids a redundant capture when errors are wrapped on their way up the stack.
+// Errors without a traced cause still capture a trace.
+//
+// Example:
+//
+//	inner := traceback.New("disk full")
+//	err := traceback.Wrap(inner, "failed to save", traceback.ReuseTrace())
+//	s.Equal(inner.Frames(), err.Frames())
+func ReuseTrace() Option {
+	return func(o *options) {
+		o.reuseTrace = true
+	}
+}
+
+// capture returns the frames of the traced cause of err when the options ask to reuse them,
+// and otherwise captures the stack, skipping skip frames counted from the caller of capture.
+func (o options) capture(err error, skip int) frame.Frames {
+	if o.reuseTrace {
+		var te *Error
+		if errors.As(err, &te) {
+			return te.frames
+		}
+	}
+	return frame.CaptureDepth(skip+2, o.depth)
+}
+
+//go:generate go run github.com/ysuzuki19/robustruct/cmd/gen/testdocgen -file=$GOFILE
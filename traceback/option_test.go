@@ -0,0 +1,89 @@
+package traceback_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ysuzuki19/collections-go/traceback"
+)
+
+type OptionSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func (s *OptionSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func TestOptionSuite(t *testing.T) {
+	suite.Run(t, new(OptionSuite))
+}
+
+func (s *OptionSuite) TestReuseTrace() {
+	// testdoc begin ReuseTrace
+	inner := traceback.New("disk full")
+	err := traceback.Wrap(inner, "failed to save", traceback.ReuseTrace())
+	s.Equal(inner.Frames(), err.Frames())
+	// testdoc end
+
+	s.NotEqual(inner.Frames(), traceback.Wrap(inner, "failed to save").Frames())
+	s.Equal(inner.Frames(), traceback.From(fmt.Errorf("ctx: %w", inner), traceback.ReuseTrace()).Frames())
+}
+
+func (s *OptionSuite) TestReuseTrace_Untraced() {
+	err := traceback.Wrap(io.EOF, "read", traceback.ReuseTrace())
+	s.Greater(err.Frames().Len(), 0)
+	s.Contains(err.Frames().String(), "(*OptionSuite).TestReuseTrace_Untraced()")
+
+	s.Nil(traceback.From(nil, traceback.ReuseTrace()))
+}
+
+func (s *OptionSuite) TestReuseTrace_Render() {
+	inner := traceback.New("disk full")
+	err := traceback.Wrap(inner, "failed to save", traceback.ReuseTrace())
+
+	// the cause shares every frame with the enclosing layer
+	trace := fmt.Sprintf("%+v", err)
+	s.Contains(trace, fmt.Sprintf("\nCaused by: disk full\n\t... %d more", inner.Frames().Len()))
+}
+
+func (s *OptionSuite) TestDepth() {
+	// testdoc begin Depth
+	err := traceback.New("shallow", traceback.Depth(1))
+	s.Equal(1, err.Frames().Len())
+	s.True(err.Frames().Truncated())
+	// testdoc end
+
+	s.Contains(err.Frames().String(), "(*OptionSuite).TestDepth()")
+	s.True(strings.HasSuffix(fmt.Sprintf("%+v", err), "\n\t... truncated"))
+
+	s.Equal(0, traceback.Wrap(io.EOF, "none", traceback.Depth(0)).Frames().Len())
+	s.False(traceback.From(io.EOF, traceback.Depth(1000)).Frames().Truncated())
+
+	// a reused trace keeps its own depth
+	inner := traceback.New("inner", traceback.Depth(2))
+	s.Equal(2, traceback.Wrap(inner, "outer", traceback.Depth(5), traceback.ReuseTrace()).Frames().Len())
+}
+
+func (s *OptionSuite) TestMaxDepth() {
+	defer func(depth int) { traceback.MaxDepth = depth }(traceback.MaxDepth)
+
+	traceback.MaxDepth = 2
+	s.Equal(2, traceback.New("new").Frames().Len())
+	s.Equal(2, traceback.Errorf("errorf").Frames().Len())
+	s.Equal(2, traceback.From(io.EOF).Frames().Len())
+	s.Equal(2, traceback.Wrapf(io.EOF, "wrapf").Frames().Len())
+	s.Equal(3, traceback.Wrap(io.EOF, "wrap", traceback.Depth(3)).Frames().Len())
+	s.Equal(3, traceback.ErrorfWith([]traceback.Option{traceback.Depth(3)}, "errorf").Frames().Len())
+	s.Equal(3, traceback.WrapfWith(io.EOF, []traceback.Option{traceback.Depth(3)}, "wrapf").Frames().Len())
+
+	traceback.MaxDepth = 0
+	s.Equal(0, traceback.New("new").Frames().Len())
+	s.Equal("new", fmt.Sprintf("%+v", traceback.New("new")))
+}